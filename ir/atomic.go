@@ -0,0 +1,90 @@
+// === [ Atomic memory ordering constraints ] ==================================
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#atomic-memory-ordering-constraints
+
+package ir
+
+import "fmt"
+
+// AtomicOrdering represents the set of memory ordering constraints understood
+// by atomic and volatile-qualifying instructions (fence, cmpxchg, atomicrmw).
+type AtomicOrdering int
+
+// Atomic memory orderings.
+const (
+	AtomicOrderingNone AtomicOrdering = iota
+	AtomicOrderingUnordered
+	AtomicOrderingMonotonic
+	AtomicOrderingAcquire
+	AtomicOrderingRelease
+	AtomicOrderingAcqRel
+	AtomicOrderingSeqCst
+)
+
+// String returns the LLVM syntax representation of the atomic memory
+// ordering.
+func (ord AtomicOrdering) String() string {
+	m := map[AtomicOrdering]string{
+		AtomicOrderingNone:      "",
+		AtomicOrderingUnordered: "unordered",
+		AtomicOrderingMonotonic: "monotonic",
+		AtomicOrderingAcquire:   "acquire",
+		AtomicOrderingRelease:   "release",
+		AtomicOrderingAcqRel:    "acq_rel",
+		AtomicOrderingSeqCst:    "seq_cst",
+	}
+	if s, ok := m[ord]; ok {
+		return s
+	}
+	panic(fmt.Sprintf("support for atomic ordering %d not yet implemented", int(ord)))
+}
+
+// === [ atomicrmw operations ] =================================================
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#atomicrmw-instruction
+
+// AtomicOp represents the set of binary operations understood by the
+// atomicrmw instruction.
+type AtomicOp int
+
+// atomicrmw binary operations.
+const (
+	AtomicOpXchg AtomicOp = iota
+	AtomicOpAdd
+	AtomicOpSub
+	AtomicOpAnd
+	AtomicOpNand
+	AtomicOpOr
+	AtomicOpXor
+	AtomicOpMax
+	AtomicOpMin
+	AtomicOpUMax
+	AtomicOpUMin
+	AtomicOpFAdd
+	AtomicOpFSub
+)
+
+// String returns the LLVM syntax representation of the atomicrmw operation.
+func (op AtomicOp) String() string {
+	m := map[AtomicOp]string{
+		AtomicOpXchg: "xchg",
+		AtomicOpAdd:  "add",
+		AtomicOpSub:  "sub",
+		AtomicOpAnd:  "and",
+		AtomicOpNand: "nand",
+		AtomicOpOr:   "or",
+		AtomicOpXor:  "xor",
+		AtomicOpMax:  "max",
+		AtomicOpMin:  "min",
+		AtomicOpUMax: "umax",
+		AtomicOpUMin: "umin",
+		AtomicOpFAdd: "fadd",
+		AtomicOpFSub: "fsub",
+	}
+	if s, ok := m[op]; ok {
+		return s
+	}
+	panic(fmt.Sprintf("support for atomicrmw operation %d not yet implemented", int(op)))
+}
@@ -0,0 +1,212 @@
+// === [ Basic blocks ] =========================================================
+
+package ir
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/llir/llvm/internal/enc"
+	"github.com/llir/llvm/ir/value"
+)
+
+// Instruction represents a non-terminator LLVM IR instruction.
+//
+// Instruction may have one of the following underlying types.
+//
+//    *ir.InstAlloca
+//    *ir.InstLoad
+//    *ir.InstStore
+//    *ir.InstFence
+//    *ir.InstCmpXchg
+//    *ir.InstAtomicRMW
+//    *ir.InstGetElementPtr
+//    *ir.InstBinOp
+//    *ir.InstICmp
+//    *ir.InstFCmp
+//    *ir.InstConv
+//    *ir.InstPhi
+//    *ir.InstCall
+type Instruction interface {
+	// Parent returns the parent basic block of the instruction.
+	Parent() *BasicBlock
+	// SetParent sets the parent basic block of the instruction.
+	SetParent(parent *BasicBlock)
+	// String returns the LLVM syntax representation of the instruction.
+	String() string
+}
+
+// Terminator represents an LLVM IR terminator instruction, which terminates a
+// basic block.
+type Terminator interface {
+	// Parent returns the parent basic block of the terminator.
+	Parent() *BasicBlock
+	// SetParent sets the parent basic block of the terminator.
+	SetParent(parent *BasicBlock)
+	// String returns the LLVM syntax representation of the terminator.
+	String() string
+}
+
+// BasicBlock represents a basic block; a sequence of non-branching
+// instructions, terminated by a control flow instruction (such as br or
+// ret).
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#functions
+type BasicBlock struct {
+	// Parent function of the basic block.
+	parent *Function
+	// Name of the basic block.
+	name string
+	// Non-terminator instructions of the basic block.
+	insts []Instruction
+	// Terminator instruction of the basic block.
+	term Terminator
+}
+
+// NewBasicBlock returns a new basic block based on the given name. Empty
+// name indicates an unnamed basic block.
+func NewBasicBlock(name string) *BasicBlock {
+	return &BasicBlock{name: name}
+}
+
+// Ident returns the identifier associated with the basic block.
+func (block *BasicBlock) Ident() string {
+	return enc.Local(block.name)
+}
+
+// Name returns the name of the basic block.
+func (block *BasicBlock) Name() string {
+	return block.name
+}
+
+// SetName sets the name of the basic block.
+func (block *BasicBlock) SetName(name string) {
+	block.name = name
+}
+
+// Parent returns the parent function of the basic block.
+func (block *BasicBlock) Parent() *Function {
+	return block.parent
+}
+
+// SetParent sets the parent function of the basic block.
+func (block *BasicBlock) SetParent(parent *Function) {
+	block.parent = parent
+}
+
+// Insts returns the non-terminator instructions of the basic block.
+func (block *BasicBlock) Insts() []Instruction {
+	return block.insts
+}
+
+// SetInsts sets the non-terminator instructions of the basic block.
+func (block *BasicBlock) SetInsts(insts []Instruction) {
+	block.insts = insts
+}
+
+// AppendInst appends the given instruction to the basic block.
+func (block *BasicBlock) AppendInst(inst Instruction) {
+	inst.SetParent(block)
+	block.insts = append(block.insts, inst)
+}
+
+// Term returns the terminator instruction of the basic block.
+func (block *BasicBlock) Term() Terminator {
+	return block.term
+}
+
+// SetTerm sets the terminator instruction of the basic block.
+func (block *BasicBlock) SetTerm(term Terminator) {
+	term.SetParent(block)
+	block.term = term
+}
+
+// String returns the LLVM syntax representation of the basic block.
+func (block *BasicBlock) String() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s:\n", block.Name())
+	for _, inst := range block.Insts() {
+		fmt.Fprintf(buf, "\t%s\n", inst)
+	}
+	fmt.Fprintf(buf, "\t%s", block.Term())
+	return buf.String()
+}
+
+// InsertInstBefore inserts the given instruction into the basic block
+// immediately before existing.
+func (block *BasicBlock) InsertInstBefore(inst, existing Instruction) {
+	block.insertInst(inst, existing, 0)
+}
+
+// InsertInstAfter inserts the given instruction into the basic block
+// immediately after existing.
+func (block *BasicBlock) InsertInstAfter(inst, existing Instruction) {
+	block.insertInst(inst, existing, 1)
+}
+
+// insertInst inserts inst at the position of existing plus offset (0 for
+// before, 1 for after).
+func (block *BasicBlock) insertInst(inst, existing Instruction, offset int) {
+	for i, cur := range block.insts {
+		if cur == existing {
+			pos := i + offset
+			insts := append(block.insts, nil)
+			copy(insts[pos+1:], insts[pos:])
+			insts[pos] = inst
+			inst.SetParent(block)
+			block.insts = insts
+			return
+		}
+	}
+	panic("ir: existing instruction not found in basic block")
+}
+
+// Uses returns the non-terminator instructions of the basic block that
+// reference v as an operand. The index is rebuilt on every call rather than
+// maintained incrementally, so it always reflects the current state of the
+// basic block.
+//
+// The basic block's terminator may also reference v; callers interested in
+// that use should inspect Term() directly, since Terminator is not an
+// Instruction.
+func (block *BasicBlock) Uses(v value.Value) []Instruction {
+	var uses []Instruction
+	for _, inst := range block.insts {
+		for _, op := range Operands(inst) {
+			if op == v {
+				uses = append(uses, inst)
+				break
+			}
+		}
+	}
+	return uses
+}
+
+// ReplaceAllUsesWith replaces every operand of the basic block's
+// instructions and terminator that reference old with new.
+func (block *BasicBlock) ReplaceAllUsesWith(old, new value.Value) {
+	for _, inst := range block.insts {
+		ReplaceOperand(inst, old, new)
+	}
+	if block.term != nil {
+		ReplaceTermOperand(block.term, old, new)
+	}
+}
+
+// assignIDs assigns unique local IDs to unnamed instructions of the basic
+// block using the given ID generator.
+func (block *BasicBlock) assignIDs(nextID func() string) {
+	for _, inst := range block.insts {
+		nv, ok := inst.(interface {
+			Name() string
+			SetName(name string)
+		})
+		if !ok {
+			continue
+		}
+		if nv.Name() == "" {
+			nv.SetName(nextID())
+		}
+	}
+}
@@ -0,0 +1,298 @@
+// === [ Builder ] =================================================================
+//
+// Builder provides a high-level API for incrementally constructing LLVM IR,
+// modelled after the emit layer of golang.org/x/tools/go/ssa: instructions
+// are created, inserted at the current insertion point, and returned to the
+// caller so that front ends do not need to manage basic block bookkeeping
+// and ID assignment by hand.
+
+package ir
+
+import (
+	"fmt"
+
+	"github.com/llir/llvm/ir/constant"
+	"github.com/llir/llvm/ir/types"
+	"github.com/llir/llvm/ir/value"
+)
+
+// Builder tracks an insertion point within a function and provides Emit*
+// helpers that create an instruction, insert it at the insertion point, and
+// return the resulting value.
+type Builder struct {
+	// Basic block of the current insertion point.
+	block *BasicBlock
+	// Instruction before which new instructions are inserted, or nil if new
+	// instructions are appended at the end of block.
+	before Instruction
+}
+
+// NewBuilder returns a new IR builder with no insertion point set.
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// Block returns the basic block of the current insertion point.
+func (b *Builder) Block() *BasicBlock {
+	return b.block
+}
+
+// SetInsertPoint sets the insertion point to the end of the given basic
+// block; subsequent Emit* calls append instructions to bb.
+func (b *Builder) SetInsertPoint(bb *BasicBlock) {
+	b.block = bb
+	b.before = nil
+}
+
+// SetInsertPointBefore sets the insertion point to immediately before the
+// given instruction; subsequent Emit* calls insert instructions before inst
+// in its parent basic block.
+func (b *Builder) SetInsertPointBefore(inst Instruction) {
+	b.block = inst.Parent()
+	b.before = inst
+}
+
+// insert inserts inst at the current insertion point of the builder.
+func (b *Builder) insert(inst Instruction) {
+	if b.block == nil {
+		panic("ir: insertion point not set; invoke SetInsertPoint or SetInsertPointBefore first")
+	}
+	inst.SetParent(b.block)
+	if b.before == nil {
+		b.block.insts = append(b.block.insts, inst)
+		return
+	}
+	insts := b.block.insts
+	for i, cur := range insts {
+		if cur == b.before {
+			insts = append(insts, nil)
+			copy(insts[i+1:], insts[i:])
+			insts[i] = inst
+			b.block.insts = insts
+			return
+		}
+	}
+	panic("ir: insertion point instruction not found in its parent basic block")
+}
+
+// setTerm sets the terminator of the current insertion point's basic block.
+func (b *Builder) setTerm(term Terminator) {
+	if b.block == nil {
+		panic("ir: insertion point not set; invoke SetInsertPoint or SetInsertPointBefore first")
+	}
+	b.block.SetTerm(term)
+}
+
+// EmitAlloca inserts a new alloca instruction at the current insertion point.
+func (b *Builder) EmitAlloca(elem types.Type) *InstAlloca {
+	inst := NewAlloca(elem)
+	b.insert(inst)
+	return inst
+}
+
+// EmitLoad inserts a new load instruction at the current insertion point.
+func (b *Builder) EmitLoad(src value.Value) *InstLoad {
+	inst := NewLoad(src)
+	b.insert(inst)
+	return inst
+}
+
+// EmitStore inserts a new store instruction at the current insertion point.
+func (b *Builder) EmitStore(src, dst value.Value) *InstStore {
+	inst := NewStore(src, dst)
+	b.insert(inst)
+	return inst
+}
+
+// EmitGEP inserts a new getelementptr instruction at the current insertion
+// point.
+func (b *Builder) EmitGEP(src value.Value, indices ...value.Value) *InstGetElementPtr {
+	inst := NewGetElementPtr(src, indices...)
+	b.insert(inst)
+	return inst
+}
+
+// EmitCall inserts a new call instruction at the current insertion point.
+func (b *Builder) EmitCall(callee value.Value, args ...value.Value) *InstCall {
+	inst := NewCall(callee, args...)
+	b.insert(inst)
+	return inst
+}
+
+// EmitBinOp inserts a new binary instruction at the current insertion point.
+func (b *Builder) EmitBinOp(op BinOp, x, y value.Value) *InstBinOp {
+	inst := NewBinOp(op, x, y)
+	b.insert(inst)
+	return inst
+}
+
+// EmitICmp inserts a new icmp instruction at the current insertion point.
+func (b *Builder) EmitICmp(pred ICmpPred, x, y value.Value) *InstICmp {
+	inst := NewICmp(pred, x, y)
+	b.insert(inst)
+	return inst
+}
+
+// EmitFCmp inserts a new fcmp instruction at the current insertion point.
+func (b *Builder) EmitFCmp(pred FCmpPred, x, y value.Value) *InstFCmp {
+	inst := NewFCmp(pred, x, y)
+	b.insert(inst)
+	return inst
+}
+
+// EmitPhi inserts a new phi instruction at the current insertion point.
+func (b *Builder) EmitPhi(typ types.Type, incs ...*Incoming) *InstPhi {
+	inst := NewPhi(typ, incs...)
+	b.insert(inst)
+	return inst
+}
+
+// EmitBr sets the terminator of the current basic block to an unconditional
+// br terminator targeting the given basic block.
+func (b *Builder) EmitBr(target *BasicBlock) *TermBr {
+	term := NewBr(target)
+	b.setTerm(term)
+	return term
+}
+
+// EmitCondBr sets the terminator of the current basic block to a conditional
+// br terminator.
+func (b *Builder) EmitCondBr(cond value.Value, targetTrue, targetFalse *BasicBlock) *TermCondBr {
+	term := NewCondBr(cond, targetTrue, targetFalse)
+	b.setTerm(term)
+	return term
+}
+
+// EmitRet sets the terminator of the current basic block to a ret
+// terminator. A nil x emits a ret void terminator.
+func (b *Builder) EmitRet(x value.Value) *TermRet {
+	term := NewRet(x)
+	b.setTerm(term)
+	return term
+}
+
+// EmitLoadStructField emits a getelementptr instruction indexing into the
+// given struct field, followed by a load of the resulting pointer, and
+// returns the loaded value.
+func (b *Builder) EmitLoadStructField(ptr value.Value, fieldIdx int64) value.Value {
+	zero := constant.NewInt(types.NewInt(32), 0)
+	idx := constant.NewInt(types.NewInt(32), fieldIdx)
+	addr := b.EmitGEP(ptr, zero, idx)
+	return b.EmitLoad(addr)
+}
+
+// EmitArrayIndex emits a getelementptr instruction indexing into element i of
+// the given array pointer.
+func (b *Builder) EmitArrayIndex(ptr, i value.Value) *InstGetElementPtr {
+	zero := constant.NewInt(types.NewInt(32), 0)
+	return b.EmitGEP(ptr, zero, i)
+}
+
+// EmitConv emits the conversion instruction required to convert v to dst.
+// Integer and floating-point values whose bit size already matches dst are
+// returned unmodified; a pointer converted to a pointer type always gets a
+// bitcast, even when the two types are the same.
+func (b *Builder) EmitConv(v value.Value, dst types.Type) value.Value {
+	src := v.Type()
+	switch srcType := src.(type) {
+	case *types.IntType:
+		switch dstType := dst.(type) {
+		case *types.IntType:
+			switch {
+			case dstType.BitSize() == srcType.BitSize():
+				return v
+			case dstType.BitSize() > srcType.BitSize():
+				return b.emitConv(ConvOpSExt, v, dst)
+			default:
+				return b.emitConv(ConvOpTrunc, v, dst)
+			}
+		case *types.FloatType:
+			return b.emitConv(ConvOpSIToFP, v, dst)
+		case *types.PointerType:
+			return b.emitConv(ConvOpIntToPtr, v, dst)
+		}
+	case *types.FloatType:
+		switch dstType := dst.(type) {
+		case *types.IntType:
+			return b.emitConv(ConvOpFPToSI, v, dst)
+		case *types.FloatType:
+			switch {
+			case dstType.BitSize() == srcType.BitSize():
+				return v
+			case dstType.BitSize() > srcType.BitSize():
+				return b.emitConv(ConvOpFPExt, v, dst)
+			default:
+				return b.emitConv(ConvOpFPTrunc, v, dst)
+			}
+		}
+	case *types.PointerType:
+		switch dst.(type) {
+		case *types.IntType:
+			return b.emitConv(ConvOpPtrToInt, v, dst)
+		case *types.PointerType:
+			return b.emitConv(ConvOpBitCast, v, dst)
+		}
+	}
+	panic(fmt.Sprintf("unable to convert value of type %s to type %s", src, dst))
+}
+
+// emitConv inserts a new conversion instruction at the current insertion
+// point.
+func (b *Builder) emitConv(op ConvOp, from value.Value, to types.Type) *InstConv {
+	inst := NewConv(op, from, to)
+	b.insert(inst)
+	return inst
+}
+
+// EmitLogicalAnd emits the short-circuit evaluation of `lhs && rhs`. genRHS
+// is invoked with the builder's insertion point set to a fresh basic block
+// and must return the i1 value of the right-hand side; it is only evaluated
+// if lhs is true.
+func (b *Builder) EmitLogicalAnd(lhs value.Value, genRHS func(b *Builder) value.Value) value.Value {
+	return b.emitShortCircuit(lhs, genRHS, true)
+}
+
+// EmitLogicalOr emits the short-circuit evaluation of `lhs || rhs`. genRHS is
+// only evaluated if lhs is false.
+func (b *Builder) EmitLogicalOr(lhs value.Value, genRHS func(b *Builder) value.Value) value.Value {
+	return b.emitShortCircuit(lhs, genRHS, false)
+}
+
+// emitShortCircuit implements the shared control flow construction of
+// EmitLogicalAnd and EmitLogicalOr: it creates a basic block in which genRHS
+// is evaluated, a merge basic block, and a phi node combining the two
+// possible outcomes.
+func (b *Builder) emitShortCircuit(lhs value.Value, genRHS func(b *Builder) value.Value, evalRHSOnTrue bool) value.Value {
+	f := b.block.Parent()
+	rhsBlock := NewBasicBlock("")
+	mergeBlock := NewBasicBlock("")
+	f.AppendBlock(rhsBlock)
+	f.AppendBlock(mergeBlock)
+
+	entryBlock := b.block
+	shortCircuit := constant.NewInt(types.NewInt(1), boolInt(!evalRHSOnTrue))
+	if evalRHSOnTrue {
+		b.EmitCondBr(lhs, rhsBlock, mergeBlock)
+	} else {
+		b.EmitCondBr(lhs, mergeBlock, rhsBlock)
+	}
+
+	b.SetInsertPoint(rhsBlock)
+	rhs := genRHS(b)
+	rhsExit := b.block
+	b.EmitBr(mergeBlock)
+
+	b.SetInsertPoint(mergeBlock)
+	return b.EmitPhi(types.NewInt(1),
+		&Incoming{X: shortCircuit, Pred: entryBlock},
+		&Incoming{X: rhs, Pred: rhsExit},
+	)
+}
+
+// boolInt converts a bool to its 0/1 integer representation.
+func boolInt(v bool) int64 {
+	if v {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,38 @@
+// === [ Calling conventions ] ===================================================
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#calling-conventions
+
+package ir
+
+import "fmt"
+
+// CallingConv specifies the calling convention of a function, invoke or call
+// instruction.
+type CallingConv string
+
+// Calling conventions.
+const (
+	// CallingConvNone specifies no explicit calling convention, which defaults
+	// to the C calling convention.
+	CallingConvNone        CallingConv = ""
+	CallingConvC           CallingConv = "ccc"
+	CallingConvFast        CallingConv = "fastcc"
+	CallingConvCold        CallingConv = "coldcc"
+	CallingConvWebKitJS    CallingConv = "webkit_jscc"
+	CallingConvSwift       CallingConv = "swiftcc"
+	CallingConvX86Stdcall  CallingConv = "x86_stdcallcc"
+	CallingConvX86Fastcall CallingConv = "x86_fastcallcc"
+	CallingConvARMAAPCS    CallingConv = "arm_aapcscc"
+)
+
+// CallingConvN returns the numeric calling convention associated with the
+// given calling convention ID.
+func CallingConvN(n int) CallingConv {
+	return CallingConv(fmt.Sprintf("cc %d", n))
+}
+
+// String returns the LLVM syntax representation of the calling convention.
+func (cc CallingConv) String() string {
+	return string(cc)
+}
@@ -0,0 +1,482 @@
+// === [ Functions ] =============================================================
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#functions
+
+package ir
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/llir/llvm/internal/enc"
+	"github.com/llir/llvm/ir/types"
+	"github.com/llir/llvm/ir/value"
+)
+
+// Function represents a function declaration or a function definition.
+//
+// A function declaration specifies the name and type of a function. A
+// function definition contains a set of basic blocks, interconnected by
+// control flow instructions (such as br), which forms the nodes in a Control
+// Flow Graph of the function.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#functions
+type Function struct {
+	// Function name.
+	name string
+	// Function signature.
+	sig *types.FuncType
+	// Function parameters.
+	params []*Param
+	// Basic blocks of the function, or nil if function declaration.
+	blocks []*BasicBlock
+	// localID represents a counter used for assigning unique local IDs to
+	// unnamed basic blocks and local variable definitions.
+	localID int
+
+	// Linkage type of the function.
+	linkage Linkage
+	// Visibility style of the function.
+	visibility Visibility
+	// DLL storage class of the function.
+	dllStorageClass DLLStorageClass
+	// Calling convention of the function.
+	callingConv CallingConv
+	// unnamed_addr qualifier; the address of the function is not
+	// significant.
+	unnamedAddr bool
+	// Function attributes (enumerated, string, or attribute group
+	// references).
+	funcAttrs []FuncAttr
+	// Section name, or empty if not present.
+	section string
+	// Comdat name, or empty if not present.
+	comdat string
+	// Garbage collector name, or empty if not present.
+	gc string
+	// Alignment in bytes, zero if not present.
+	align int
+	// Prefix data, or nil if not present.
+	prefix value.Value
+	// Personality function, or nil if not present.
+	personality value.Value
+}
+
+// NewFunction returns a new function based on the given name, return type and
+// function parameters.
+func NewFunction(name string, ret types.Type, params ...*Param) *Function {
+	var paramTypes []types.Type
+	for _, param := range params {
+		paramTypes = append(paramTypes, param.Type())
+	}
+	sig := types.NewFunc(ret, paramTypes...)
+	return &Function{name: name, sig: sig, params: params}
+}
+
+// Name returns the name of the function.
+func (f *Function) Name() string {
+	return f.name
+}
+
+// SetName sets the name of the function.
+func (f *Function) SetName(name string) {
+	f.name = name
+}
+
+// Sig returns the function signature.
+func (f *Function) Sig() *types.FuncType {
+	return f.sig
+}
+
+// Type returns the type of the function.
+func (f *Function) Type() types.Type {
+	return f.sig
+}
+
+// Ident returns the identifier associated with the function.
+func (f *Function) Ident() string {
+	return enc.Global(f.name)
+}
+
+// Params returns the parameters of the function.
+func (f *Function) Params() []*Param {
+	return f.params
+}
+
+// AppendParam appends the given parameter to the function.
+func (f *Function) AppendParam(param *Param) {
+	f.params = append(f.params, param)
+}
+
+// Blocks returns the basic blocks of the function.
+func (f *Function) Blocks() []*BasicBlock {
+	return f.blocks
+}
+
+// AppendBlock appends the given basic block to the function.
+//
+// The caller is responsible for invoking AssignIDs once all basic blocks have
+// been added to the function.
+func (f *Function) AppendBlock(block *BasicBlock) {
+	block.SetParent(f)
+	f.blocks = append(f.blocks, block)
+}
+
+// RemoveBlock removes the given basic block from the function.
+//
+// The caller is responsible for invoking AssignIDs and fixing up any phi
+// instructions that reference bb as an incoming predecessor.
+func (f *Function) RemoveBlock(bb *BasicBlock) {
+	for i, block := range f.blocks {
+		if block == bb {
+			f.blocks = append(f.blocks[:i], f.blocks[i+1:]...)
+			return
+		}
+	}
+}
+
+// AssignIDs assigns unique local IDs to unnamed basic blocks and local
+// variable definitions of the function. It may be invoked repeatedly as the
+// function is mutated; already named blocks and instructions are left
+// untouched, so previously assigned IDs never change.
+func (f *Function) AssignIDs() {
+	f.localID = f.maxAssignedID() + 1
+	for _, block := range f.blocks {
+		if block.Name() == "" {
+			block.SetName(f.nextID())
+		}
+		block.assignIDs(f.nextID)
+	}
+}
+
+// maxAssignedID returns the highest numeric local ID already assigned to a
+// basic block or instruction of the function, or -1 if none has been
+// assigned yet. Starting the next AssignIDs pass above this value ensures
+// IDs consumed by an earlier pass are never handed out again.
+func (f *Function) maxAssignedID() int {
+	max := -1
+	consider := func(name string) {
+		if id, err := strconv.Atoi(name); err == nil && id > max {
+			max = id
+		}
+	}
+	for _, block := range f.blocks {
+		consider(block.Name())
+		for _, inst := range block.Insts() {
+			if nv, ok := inst.(interface{ Name() string }); ok {
+				consider(nv.Name())
+			}
+		}
+	}
+	return max
+}
+
+// nextID returns the next unique local ID of the function, and increments
+// the internal localID counter.
+func (f *Function) nextID() string {
+	id := strconv.Itoa(f.localID)
+	f.localID++
+	return id
+}
+
+// Entry returns the entry basic block of the function, or nil if the
+// function has no basic blocks.
+func (f *Function) Entry() *BasicBlock {
+	if len(f.blocks) == 0 {
+		return nil
+	}
+	return f.blocks[0]
+}
+
+// InsertBlockBefore inserts the given basic block into the function
+// immediately before existing.
+func (f *Function) InsertBlockBefore(block, existing *BasicBlock) {
+	f.insertBlock(block, existing, 0)
+}
+
+// InsertBlockAfter inserts the given basic block into the function
+// immediately after existing.
+func (f *Function) InsertBlockAfter(block, existing *BasicBlock) {
+	f.insertBlock(block, existing, 1)
+}
+
+// insertBlock inserts block at the position of existing plus offset (0 for
+// before, 1 for after).
+func (f *Function) insertBlock(block, existing *BasicBlock, offset int) {
+	for i, bb := range f.blocks {
+		if bb == existing {
+			pos := i + offset
+			f.blocks = append(f.blocks, nil)
+			copy(f.blocks[pos+1:], f.blocks[pos:])
+			f.blocks[pos] = block
+			block.SetParent(f)
+			return
+		}
+	}
+	panic("ir: existing basic block not found in function")
+}
+
+// Preds returns the predecessor basic blocks of bb within the function, as
+// determined by the terminators of its basic blocks.
+func (f *Function) Preds(bb *BasicBlock) []*BasicBlock {
+	var preds []*BasicBlock
+	for _, block := range f.blocks {
+		for _, succ := range f.Succs(block) {
+			if succ == bb {
+				preds = append(preds, block)
+				break
+			}
+		}
+	}
+	return preds
+}
+
+// Succs returns the successor basic blocks of bb, as determined by its
+// terminator.
+func (f *Function) Succs(bb *BasicBlock) []*BasicBlock {
+	switch term := bb.Term().(type) {
+	case *TermBr:
+		return []*BasicBlock{term.Target()}
+	case *TermCondBr:
+		return []*BasicBlock{term.TargetTrue(), term.TargetFalse()}
+	default:
+		// ret and other terminators have no successors.
+		return nil
+	}
+}
+
+// Linkage returns the linkage type of the function.
+func (f *Function) Linkage() Linkage {
+	return f.linkage
+}
+
+// SetLinkage sets the linkage type of the function.
+func (f *Function) SetLinkage(linkage Linkage) {
+	f.linkage = linkage
+}
+
+// Visibility returns the visibility style of the function.
+func (f *Function) Visibility() Visibility {
+	return f.visibility
+}
+
+// SetVisibility sets the visibility style of the function.
+func (f *Function) SetVisibility(visibility Visibility) {
+	f.visibility = visibility
+}
+
+// DLLStorageClass returns the DLL storage class of the function.
+func (f *Function) DLLStorageClass() DLLStorageClass {
+	return f.dllStorageClass
+}
+
+// SetDLLStorageClass sets the DLL storage class of the function.
+func (f *Function) SetDLLStorageClass(class DLLStorageClass) {
+	f.dllStorageClass = class
+}
+
+// CallingConv returns the calling convention of the function.
+func (f *Function) CallingConv() CallingConv {
+	return f.callingConv
+}
+
+// SetCallingConv sets the calling convention of the function.
+func (f *Function) SetCallingConv(callingConv CallingConv) {
+	f.callingConv = callingConv
+}
+
+// UnnamedAddr reports whether the function carries the unnamed_addr
+// qualifier, i.e. whether its address is not significant.
+func (f *Function) UnnamedAddr() bool {
+	return f.unnamedAddr
+}
+
+// SetUnnamedAddr sets the unnamed_addr qualifier of the function.
+func (f *Function) SetUnnamedAddr(unnamedAddr bool) {
+	f.unnamedAddr = unnamedAddr
+}
+
+// FuncAttrs returns the function attributes of the function.
+func (f *Function) FuncAttrs() []FuncAttr {
+	return f.funcAttrs
+}
+
+// SetFuncAttrs sets the function attributes of the function.
+func (f *Function) SetFuncAttrs(funcAttrs []FuncAttr) {
+	f.funcAttrs = funcAttrs
+}
+
+// Section returns the section name of the function.
+func (f *Function) Section() string {
+	return f.section
+}
+
+// SetSection sets the section name of the function.
+func (f *Function) SetSection(section string) {
+	f.section = section
+}
+
+// Comdat returns the comdat name of the function.
+func (f *Function) Comdat() string {
+	return f.comdat
+}
+
+// SetComdat sets the comdat name of the function.
+func (f *Function) SetComdat(comdat string) {
+	f.comdat = comdat
+}
+
+// GC returns the garbage collector name of the function.
+func (f *Function) GC() string {
+	return f.gc
+}
+
+// SetGC sets the garbage collector name of the function.
+func (f *Function) SetGC(gc string) {
+	f.gc = gc
+}
+
+// Align returns the alignment in bytes of the function, or zero if not
+// present.
+func (f *Function) Align() int {
+	return f.align
+}
+
+// SetAlign sets the alignment in bytes of the function.
+func (f *Function) SetAlign(align int) {
+	f.align = align
+}
+
+// Prefix returns the prefix data of the function, and a boolean indicating if
+// prefix data is present.
+func (f *Function) Prefix() (value.Value, bool) {
+	if f.prefix != nil {
+		return f.prefix, true
+	}
+	return nil, false
+}
+
+// SetPrefix sets the prefix data of the function.
+func (f *Function) SetPrefix(prefix value.Value) {
+	f.prefix = prefix
+}
+
+// Personality returns the personality function of the function, and a
+// boolean indicating if a personality function is present.
+func (f *Function) Personality() (value.Value, bool) {
+	if f.personality != nil {
+		return f.personality, true
+	}
+	return nil, false
+}
+
+// SetPersonality sets the personality function of the function.
+func (f *Function) SetPersonality(personality value.Value) {
+	f.personality = personality
+}
+
+// String returns the LLVM syntax representation of the function.
+func (f *Function) String() string {
+	buf := &bytes.Buffer{}
+	if f.blocks == nil {
+		buf.WriteString("declare ")
+	} else {
+		buf.WriteString("define ")
+	}
+	if linkage := f.Linkage(); linkage != LinkageNone {
+		fmt.Fprintf(buf, "%s ", linkage)
+	}
+	if visibility := f.Visibility(); visibility != VisibilityNone {
+		fmt.Fprintf(buf, "%s ", visibility)
+	}
+	if class := f.DLLStorageClass(); class != DLLStorageClassNone {
+		fmt.Fprintf(buf, "%s ", class)
+	}
+	if cc := f.CallingConv(); cc != CallingConvNone {
+		fmt.Fprintf(buf, "%s ", cc)
+	}
+	paramsBuf := &bytes.Buffer{}
+	for i, param := range f.Params() {
+		if i > 0 {
+			paramsBuf.WriteString(", ")
+		}
+		fmt.Fprintf(paramsBuf, "%s %s", param.Type(), param.Ident())
+	}
+	if f.Sig().Variadic() {
+		if len(f.Params()) > 0 {
+			paramsBuf.WriteString(", ")
+		}
+		paramsBuf.WriteString("...")
+	}
+	fmt.Fprintf(buf, "%s %s(%s)", f.Sig().Ret(), f.Ident(), paramsBuf)
+	if f.UnnamedAddr() {
+		buf.WriteString(" unnamed_addr")
+	}
+	for _, attr := range f.FuncAttrs() {
+		fmt.Fprintf(buf, " %s", attr)
+	}
+	if section := f.Section(); len(section) > 0 {
+		fmt.Fprintf(buf, " section %q", section)
+	}
+	if comdat := f.Comdat(); len(comdat) > 0 {
+		fmt.Fprintf(buf, " comdat($%s)", comdat)
+	}
+	if align := f.Align(); align != 0 {
+		fmt.Fprintf(buf, " align %d", align)
+	}
+	if gc := f.GC(); len(gc) > 0 {
+		fmt.Fprintf(buf, " gc %q", gc)
+	}
+	if prefix, ok := f.Prefix(); ok {
+		fmt.Fprintf(buf, " prefix %s %s", prefix.Type(), prefix.Ident())
+	}
+	if personality, ok := f.Personality(); ok {
+		fmt.Fprintf(buf, " personality %s %s", personality.Type(), personality.Ident())
+	}
+	if f.blocks == nil {
+		return buf.String()
+	}
+	buf.WriteString(" {\n")
+	for _, block := range f.blocks {
+		fmt.Fprintln(buf, block)
+	}
+	buf.WriteString("}")
+	return buf.String()
+}
+
+// Param represents an LLVM IR function parameter.
+type Param struct {
+	// Parameter name.
+	name string
+	// Parameter type.
+	typ types.Type
+}
+
+// NewParam returns a new function parameter based on the given name and
+// type.
+func NewParam(name string, typ types.Type) *Param {
+	return &Param{name: name, typ: typ}
+}
+
+// Type returns the type of the function parameter.
+func (param *Param) Type() types.Type {
+	return param.typ
+}
+
+// Ident returns the identifier associated with the function parameter.
+func (param *Param) Ident() string {
+	return enc.Local(param.name)
+}
+
+// Name returns the name of the function parameter.
+func (param *Param) Name() string {
+	return param.name
+}
+
+// SetName sets the name of the function parameter.
+func (param *Param) SetName(name string) {
+	param.name = name
+}
@@ -0,0 +1,74 @@
+// === [ Function attributes ] ===================================================
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#function-attributes
+
+package ir
+
+import "fmt"
+
+// FuncAttr is a function attribute, one of EnumAttr, StringAttr or
+// AttrGroupID.
+type FuncAttr interface {
+	fmt.Stringer
+	// isFuncAttr ensures that only function attributes can be assigned to the
+	// FuncAttr interface.
+	isFuncAttr()
+}
+
+// EnumAttr is an enumerated function attribute (e.g. noreturn, nounwind).
+type EnumAttr string
+
+// Enumerated function attributes.
+const (
+	AttrNoReturn     EnumAttr = "noreturn"
+	AttrNoUnwind     EnumAttr = "nounwind"
+	AttrReadOnly     EnumAttr = "readonly"
+	AttrReadNone     EnumAttr = "readnone"
+	AttrAlwaysInline EnumAttr = "alwaysinline"
+	AttrNoInline     EnumAttr = "noinline"
+	AttrOptNone      EnumAttr = "optnone"
+	AttrSSP          EnumAttr = "ssp"
+	AttrSSPStrong    EnumAttr = "sspstrong"
+	AttrUWTable      EnumAttr = "uwtable"
+)
+
+// String returns the LLVM syntax representation of the enumerated function
+// attribute.
+func (attr EnumAttr) String() string {
+	return string(attr)
+}
+
+func (EnumAttr) isFuncAttr() {}
+
+// StringAttr is an arbitrary string function attribute (e.g.
+// "no-jump-tables"="true").
+type StringAttr struct {
+	// Attribute key.
+	Key string
+	// Attribute value; or empty if a key-only attribute.
+	Value string
+}
+
+// String returns the LLVM syntax representation of the string function
+// attribute.
+func (attr StringAttr) String() string {
+	if len(attr.Value) > 0 {
+		return fmt.Sprintf("%q=%q", attr.Key, attr.Value)
+	}
+	return fmt.Sprintf("%q", attr.Key)
+}
+
+func (StringAttr) isFuncAttr() {}
+
+// AttrGroupID is a reference to an attribute group (e.g. #0) defined
+// elsewhere in the module.
+type AttrGroupID int
+
+// String returns the LLVM syntax representation of the attribute group
+// reference.
+func (id AttrGroupID) String() string {
+	return fmt.Sprintf("#%d", int(id))
+}
+
+func (AttrGroupID) isFuncAttr() {}
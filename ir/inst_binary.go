@@ -0,0 +1,154 @@
+// === [ Binary instructions ] ===================================================
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#binary-operations
+
+package ir
+
+import (
+	"fmt"
+
+	"github.com/llir/llvm/internal/enc"
+	"github.com/llir/llvm/ir/types"
+	"github.com/llir/llvm/ir/value"
+)
+
+// BinOp specifies the operation of a binary instruction.
+type BinOp int
+
+// Binary operations.
+const (
+	BinOpAdd BinOp = iota
+	BinOpFAdd
+	BinOpSub
+	BinOpFSub
+	BinOpMul
+	BinOpFMul
+	BinOpUDiv
+	BinOpSDiv
+	BinOpFDiv
+	BinOpURem
+	BinOpSRem
+	BinOpFRem
+	BinOpShl
+	BinOpLShr
+	BinOpAShr
+	BinOpAnd
+	BinOpOr
+	BinOpXor
+)
+
+// String returns the LLVM syntax representation of the binary operation.
+func (op BinOp) String() string {
+	m := map[BinOp]string{
+		BinOpAdd:  "add",
+		BinOpFAdd: "fadd",
+		BinOpSub:  "sub",
+		BinOpFSub: "fsub",
+		BinOpMul:  "mul",
+		BinOpFMul: "fmul",
+		BinOpUDiv: "udiv",
+		BinOpSDiv: "sdiv",
+		BinOpFDiv: "fdiv",
+		BinOpURem: "urem",
+		BinOpSRem: "srem",
+		BinOpFRem: "frem",
+		BinOpShl:  "shl",
+		BinOpLShr: "lshr",
+		BinOpAShr: "ashr",
+		BinOpAnd:  "and",
+		BinOpOr:   "or",
+		BinOpXor:  "xor",
+	}
+	if s, ok := m[op]; ok {
+		return s
+	}
+	panic(fmt.Sprintf("support for binary operation %d not yet implemented", int(op)))
+}
+
+// InstBinOp represents a binary instruction (e.g. add, sub, mul).
+type InstBinOp struct {
+	// Parent basic block.
+	parent *BasicBlock
+	// Name of the local variable associated with the instruction.
+	name string
+	// Binary operation.
+	op BinOp
+	// Left-hand side operand.
+	x value.Value
+	// Right-hand side operand.
+	y value.Value
+}
+
+// NewBinOp returns a new binary instruction based on the given binary
+// operation and operands.
+func NewBinOp(op BinOp, x, y value.Value) *InstBinOp {
+	return &InstBinOp{op: op, x: x, y: y}
+}
+
+// Type returns the type of the instruction.
+func (inst *InstBinOp) Type() types.Type {
+	return inst.x.Type()
+}
+
+// Ident returns the identifier associated with the instruction.
+func (inst *InstBinOp) Ident() string {
+	return enc.Local(inst.name)
+}
+
+// Name returns the name of the local variable associated with the
+// instruction.
+func (inst *InstBinOp) Name() string {
+	return inst.name
+}
+
+// SetName sets the name of the local variable associated with the
+// instruction.
+func (inst *InstBinOp) SetName(name string) {
+	inst.name = name
+}
+
+// String returns the LLVM syntax representation of the instruction.
+func (inst *InstBinOp) String() string {
+	return fmt.Sprintf("%s = %s %s %s, %s",
+		inst.Ident(),
+		inst.Op(),
+		inst.X().Type(),
+		inst.X().Ident(),
+		inst.Y().Ident())
+}
+
+// Parent returns the parent basic block of the instruction.
+func (inst *InstBinOp) Parent() *BasicBlock {
+	return inst.parent
+}
+
+// SetParent sets the parent basic block of the instruction.
+func (inst *InstBinOp) SetParent(parent *BasicBlock) {
+	inst.parent = parent
+}
+
+// Op returns the binary operation of the instruction.
+func (inst *InstBinOp) Op() BinOp {
+	return inst.op
+}
+
+// X returns the left-hand side operand of the instruction.
+func (inst *InstBinOp) X() value.Value {
+	return inst.x
+}
+
+// SetX sets the left-hand side operand of the instruction.
+func (inst *InstBinOp) SetX(x value.Value) {
+	inst.x = x
+}
+
+// Y returns the right-hand side operand of the instruction.
+func (inst *InstBinOp) Y() value.Value {
+	return inst.y
+}
+
+// SetY sets the right-hand side operand of the instruction.
+func (inst *InstBinOp) SetY(y value.Value) {
+	inst.y = y
+}
@@ -0,0 +1,255 @@
+// === [ Compare instructions ] ===================================================
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#icmp-instruction
+//    http://llvm.org/docs/LangRef.html#fcmp-instruction
+
+package ir
+
+import (
+	"fmt"
+
+	"github.com/llir/llvm/internal/enc"
+	"github.com/llir/llvm/ir/types"
+	"github.com/llir/llvm/ir/value"
+)
+
+// ICmpPred specifies the condition of an icmp instruction.
+type ICmpPred int
+
+// icmp conditions.
+const (
+	ICmpPredEQ ICmpPred = iota
+	ICmpPredNE
+	ICmpPredUGT
+	ICmpPredUGE
+	ICmpPredULT
+	ICmpPredULE
+	ICmpPredSGT
+	ICmpPredSGE
+	ICmpPredSLT
+	ICmpPredSLE
+)
+
+// String returns the LLVM syntax representation of the icmp condition.
+func (pred ICmpPred) String() string {
+	m := map[ICmpPred]string{
+		ICmpPredEQ:  "eq",
+		ICmpPredNE:  "ne",
+		ICmpPredUGT: "ugt",
+		ICmpPredUGE: "uge",
+		ICmpPredULT: "ult",
+		ICmpPredULE: "ule",
+		ICmpPredSGT: "sgt",
+		ICmpPredSGE: "sge",
+		ICmpPredSLT: "slt",
+		ICmpPredSLE: "sle",
+	}
+	if s, ok := m[pred]; ok {
+		return s
+	}
+	panic(fmt.Sprintf("support for icmp condition %d not yet implemented", int(pred)))
+}
+
+// InstICmp represents an icmp instruction.
+type InstICmp struct {
+	parent *BasicBlock
+	name   string
+	pred   ICmpPred
+	x, y   value.Value
+}
+
+// NewICmp returns a new icmp instruction based on the given condition and
+// operands.
+func NewICmp(pred ICmpPred, x, y value.Value) *InstICmp {
+	return &InstICmp{pred: pred, x: x, y: y}
+}
+
+// Type returns the type of the instruction.
+func (inst *InstICmp) Type() types.Type {
+	return types.NewInt(1)
+}
+
+// Ident returns the identifier associated with the instruction.
+func (inst *InstICmp) Ident() string {
+	return enc.Local(inst.name)
+}
+
+// Name returns the name of the local variable associated with the
+// instruction.
+func (inst *InstICmp) Name() string {
+	return inst.name
+}
+
+// SetName sets the name of the local variable associated with the
+// instruction.
+func (inst *InstICmp) SetName(name string) {
+	inst.name = name
+}
+
+// String returns the LLVM syntax representation of the instruction.
+func (inst *InstICmp) String() string {
+	return fmt.Sprintf("%s = icmp %s %s %s, %s",
+		inst.Ident(), inst.Pred(), inst.X().Type(), inst.X().Ident(), inst.Y().Ident())
+}
+
+// Parent returns the parent basic block of the instruction.
+func (inst *InstICmp) Parent() *BasicBlock {
+	return inst.parent
+}
+
+// SetParent sets the parent basic block of the instruction.
+func (inst *InstICmp) SetParent(parent *BasicBlock) {
+	inst.parent = parent
+}
+
+// Pred returns the condition of the icmp instruction.
+func (inst *InstICmp) Pred() ICmpPred {
+	return inst.pred
+}
+
+// X returns the left-hand side operand of the icmp instruction.
+func (inst *InstICmp) X() value.Value {
+	return inst.x
+}
+
+// SetX sets the left-hand side operand of the icmp instruction.
+func (inst *InstICmp) SetX(x value.Value) {
+	inst.x = x
+}
+
+// Y returns the right-hand side operand of the icmp instruction.
+func (inst *InstICmp) Y() value.Value {
+	return inst.y
+}
+
+// SetY sets the right-hand side operand of the icmp instruction.
+func (inst *InstICmp) SetY(y value.Value) {
+	inst.y = y
+}
+
+// FCmpPred specifies the condition of an fcmp instruction.
+type FCmpPred int
+
+// fcmp conditions.
+const (
+	FCmpPredFalse FCmpPred = iota
+	FCmpPredOEQ
+	FCmpPredOGT
+	FCmpPredOGE
+	FCmpPredOLT
+	FCmpPredOLE
+	FCmpPredONE
+	FCmpPredORD
+	FCmpPredUEQ
+	FCmpPredUGT
+	FCmpPredUGE
+	FCmpPredULT
+	FCmpPredULE
+	FCmpPredUNE
+	FCmpPredUNO
+	FCmpPredTrue
+)
+
+// String returns the LLVM syntax representation of the fcmp condition.
+func (pred FCmpPred) String() string {
+	m := map[FCmpPred]string{
+		FCmpPredFalse: "false",
+		FCmpPredOEQ:   "oeq",
+		FCmpPredOGT:   "ogt",
+		FCmpPredOGE:   "oge",
+		FCmpPredOLT:   "olt",
+		FCmpPredOLE:   "ole",
+		FCmpPredONE:   "one",
+		FCmpPredORD:   "ord",
+		FCmpPredUEQ:   "ueq",
+		FCmpPredUGT:   "ugt",
+		FCmpPredUGE:   "uge",
+		FCmpPredULT:   "ult",
+		FCmpPredULE:   "ule",
+		FCmpPredUNE:   "une",
+		FCmpPredUNO:   "uno",
+		FCmpPredTrue:  "true",
+	}
+	if s, ok := m[pred]; ok {
+		return s
+	}
+	panic(fmt.Sprintf("support for fcmp condition %d not yet implemented", int(pred)))
+}
+
+// InstFCmp represents an fcmp instruction.
+type InstFCmp struct {
+	parent *BasicBlock
+	name   string
+	pred   FCmpPred
+	x, y   value.Value
+}
+
+// NewFCmp returns a new fcmp instruction based on the given condition and
+// operands.
+func NewFCmp(pred FCmpPred, x, y value.Value) *InstFCmp {
+	return &InstFCmp{pred: pred, x: x, y: y}
+}
+
+// Type returns the type of the instruction.
+func (inst *InstFCmp) Type() types.Type {
+	return types.NewInt(1)
+}
+
+// Ident returns the identifier associated with the instruction.
+func (inst *InstFCmp) Ident() string {
+	return enc.Local(inst.name)
+}
+
+// Name returns the name of the local variable associated with the
+// instruction.
+func (inst *InstFCmp) Name() string {
+	return inst.name
+}
+
+// SetName sets the name of the local variable associated with the
+// instruction.
+func (inst *InstFCmp) SetName(name string) {
+	inst.name = name
+}
+
+// String returns the LLVM syntax representation of the instruction.
+func (inst *InstFCmp) String() string {
+	return fmt.Sprintf("%s = fcmp %s %s %s, %s",
+		inst.Ident(), inst.Pred(), inst.X().Type(), inst.X().Ident(), inst.Y().Ident())
+}
+
+// Parent returns the parent basic block of the instruction.
+func (inst *InstFCmp) Parent() *BasicBlock {
+	return inst.parent
+}
+
+// SetParent sets the parent basic block of the instruction.
+func (inst *InstFCmp) SetParent(parent *BasicBlock) {
+	inst.parent = parent
+}
+
+// Pred returns the condition of the fcmp instruction.
+func (inst *InstFCmp) Pred() FCmpPred {
+	return inst.pred
+}
+
+// X returns the left-hand side operand of the fcmp instruction.
+func (inst *InstFCmp) X() value.Value {
+	return inst.x
+}
+
+// SetX sets the left-hand side operand of the fcmp instruction.
+func (inst *InstFCmp) SetX(x value.Value) {
+	inst.x = x
+}
+
+// Y returns the right-hand side operand of the fcmp instruction.
+func (inst *InstFCmp) Y() value.Value {
+	return inst.y
+}
+
+// SetY sets the right-hand side operand of the fcmp instruction.
+func (inst *InstFCmp) SetY(y value.Value) {
+	inst.y = y
+}
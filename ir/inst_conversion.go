@@ -0,0 +1,124 @@
+// === [ Conversion instructions ] =================================================
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#conversion-operations
+
+package ir
+
+import (
+	"fmt"
+
+	"github.com/llir/llvm/internal/enc"
+	"github.com/llir/llvm/ir/types"
+	"github.com/llir/llvm/ir/value"
+)
+
+// ConvOp specifies the operation of a conversion instruction.
+type ConvOp int
+
+// Conversion operations.
+const (
+	ConvOpTrunc ConvOp = iota
+	ConvOpZExt
+	ConvOpSExt
+	ConvOpFPTrunc
+	ConvOpFPExt
+	ConvOpFPToUI
+	ConvOpFPToSI
+	ConvOpUIToFP
+	ConvOpSIToFP
+	ConvOpPtrToInt
+	ConvOpIntToPtr
+	ConvOpBitCast
+)
+
+// String returns the LLVM syntax representation of the conversion operation.
+func (op ConvOp) String() string {
+	m := map[ConvOp]string{
+		ConvOpTrunc:    "trunc",
+		ConvOpZExt:     "zext",
+		ConvOpSExt:     "sext",
+		ConvOpFPTrunc:  "fptrunc",
+		ConvOpFPExt:    "fpext",
+		ConvOpFPToUI:   "fptoui",
+		ConvOpFPToSI:   "fptosi",
+		ConvOpUIToFP:   "uitofp",
+		ConvOpSIToFP:   "sitofp",
+		ConvOpPtrToInt: "ptrtoint",
+		ConvOpIntToPtr: "inttoptr",
+		ConvOpBitCast:  "bitcast",
+	}
+	if s, ok := m[op]; ok {
+		return s
+	}
+	panic(fmt.Sprintf("support for conversion operation %d not yet implemented", int(op)))
+}
+
+// InstConv represents a conversion instruction.
+type InstConv struct {
+	parent *BasicBlock
+	name   string
+	op     ConvOp
+	from   value.Value
+	to     types.Type
+}
+
+// NewConv returns a new conversion instruction based on the given conversion
+// operation, source value and target type.
+func NewConv(op ConvOp, from value.Value, to types.Type) *InstConv {
+	return &InstConv{op: op, from: from, to: to}
+}
+
+// Type returns the type of the instruction.
+func (inst *InstConv) Type() types.Type {
+	return inst.to
+}
+
+// Ident returns the identifier associated with the instruction.
+func (inst *InstConv) Ident() string {
+	return enc.Local(inst.name)
+}
+
+// Name returns the name of the local variable associated with the
+// instruction.
+func (inst *InstConv) Name() string {
+	return inst.name
+}
+
+// SetName sets the name of the local variable associated with the
+// instruction.
+func (inst *InstConv) SetName(name string) {
+	inst.name = name
+}
+
+// Parent returns the parent basic block of the instruction.
+func (inst *InstConv) Parent() *BasicBlock {
+	return inst.parent
+}
+
+// SetParent sets the parent basic block of the instruction.
+func (inst *InstConv) SetParent(parent *BasicBlock) {
+	inst.parent = parent
+}
+
+// Op returns the conversion operation of the instruction.
+func (inst *InstConv) Op() ConvOp {
+	return inst.op
+}
+
+// From returns the source value of the conversion instruction.
+func (inst *InstConv) From() value.Value {
+	return inst.from
+}
+
+// SetFrom sets the source value of the conversion instruction.
+func (inst *InstConv) SetFrom(from value.Value) {
+	inst.from = from
+}
+
+// String returns the LLVM syntax representation of the instruction.
+func (inst *InstConv) String() string {
+	from := inst.From()
+	return fmt.Sprintf("%s = %s %s %s to %s",
+		inst.Ident(), inst.Op(), from.Type(), from.Ident(), inst.Type())
+}
@@ -242,10 +242,441 @@ func (inst *InstStore) SetDst(dst value.Value) {
 
 // --- [ fence ] ---------------------------------------------------------------
 
+// InstFence represents a fence instruction.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#fence-instruction
+type InstFence struct {
+	// Parent basic block.
+	parent *BasicBlock
+	// Name of the local variable associated with the instruction.
+	name string
+	// Atomic memory ordering constraints.
+	ordering AtomicOrdering
+	// Synchronization scope; true if singlethread.
+	singleThread bool
+}
+
+// NewFence returns a new fence instruction based on the given atomic memory
+// ordering constraints.
+func NewFence(ordering AtomicOrdering) *InstFence {
+	return &InstFence{ordering: ordering}
+}
+
+// Type returns the type of the instruction.
+func (inst *InstFence) Type() types.Type {
+	return types.Void
+}
+
+// Ident returns the identifier associated with the instruction.
+func (inst *InstFence) Ident() string {
+	return enc.Local(inst.name)
+}
+
+// Name returns the name of the local variable associated with the
+// instruction.
+func (inst *InstFence) Name() string {
+	return inst.name
+}
+
+// SetName sets the name of the local variable associated with the
+// instruction.
+func (inst *InstFence) SetName(name string) {
+	inst.name = name
+}
+
+// String returns the LLVM syntax representation of the instruction.
+func (inst *InstFence) String() string {
+	if inst.SingleThread() {
+		return fmt.Sprintf("fence singlethread %s", inst.Ordering())
+	}
+	return fmt.Sprintf("fence %s", inst.Ordering())
+}
+
+// Parent returns the parent basic block of the instruction.
+func (inst *InstFence) Parent() *BasicBlock {
+	return inst.parent
+}
+
+// SetParent sets the parent basic block of the instruction.
+func (inst *InstFence) SetParent(parent *BasicBlock) {
+	inst.parent = parent
+}
+
+// Ordering returns the atomic memory ordering constraints of the fence
+// instruction.
+func (inst *InstFence) Ordering() AtomicOrdering {
+	return inst.ordering
+}
+
+// SetOrdering sets the atomic memory ordering constraints of the fence
+// instruction.
+func (inst *InstFence) SetOrdering(ordering AtomicOrdering) {
+	inst.ordering = ordering
+}
+
+// SingleThread reports whether the fence instruction is synchronized with a
+// single thread only, rather than all threads.
+func (inst *InstFence) SingleThread() bool {
+	return inst.singleThread
+}
+
+// SetSingleThread sets the synchronization scope of the fence instruction.
+func (inst *InstFence) SetSingleThread(singleThread bool) {
+	inst.singleThread = singleThread
+}
+
 // --- [ cmpxchg ] -------------------------------------------------------------
 
+// InstCmpXchg represents a cmpxchg instruction.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#cmpxchg-instruction
+type InstCmpXchg struct {
+	// Parent basic block.
+	parent *BasicBlock
+	// Name of the local variable associated with the instruction.
+	name string
+	// Type of the instruction; a {T, i1} struct, where T is the type of cmp
+	// and new.
+	typ *types.StructType
+	// Address to read from, compare against and store to.
+	addr value.Value
+	// Value to compare against the value currently stored at addr.
+	cmp value.Value
+	// New value to store at addr if the comparison is successful.
+	new value.Value
+	// Atomic memory ordering constraints on success.
+	successOrdering AtomicOrdering
+	// Atomic memory ordering constraints on failure.
+	failureOrdering AtomicOrdering
+	// Synchronization scope; true if singlethread.
+	singleThread bool
+	// Weak, rather than strong, comparison; a weak cmpxchg may spuriously
+	// fail.
+	weak bool
+	// Volatile operation.
+	volatile bool
+}
+
+// NewCmpXchg returns a new cmpxchg instruction based on the given address,
+// comparison value, replacement value and atomic memory ordering constraints
+// on success and failure.
+func NewCmpXchg(addr, cmp, new value.Value, successOrdering, failureOrdering AtomicOrdering) *InstCmpXchg {
+	t, ok := addr.Type().(*types.PointerType)
+	if !ok {
+		panic(fmt.Sprintf("invalid address type; expected *types.PointerType, got %T", addr.Type()))
+	}
+	typ := types.NewStruct(t.Elem(), types.NewInt(1))
+	return &InstCmpXchg{typ: typ, addr: addr, cmp: cmp, new: new, successOrdering: successOrdering, failureOrdering: failureOrdering}
+}
+
+// Type returns the type of the instruction.
+func (inst *InstCmpXchg) Type() types.Type {
+	return inst.typ
+}
+
+// Ident returns the identifier associated with the instruction.
+func (inst *InstCmpXchg) Ident() string {
+	return enc.Local(inst.name)
+}
+
+// Name returns the name of the local variable associated with the
+// instruction.
+func (inst *InstCmpXchg) Name() string {
+	return inst.name
+}
+
+// SetName sets the name of the local variable associated with the
+// instruction.
+func (inst *InstCmpXchg) SetName(name string) {
+	inst.name = name
+}
+
+// String returns the LLVM syntax representation of the instruction.
+func (inst *InstCmpXchg) String() string {
+	buf := &bytes.Buffer{}
+	buf.WriteString(inst.Ident())
+	buf.WriteString(" = cmpxchg ")
+	if inst.Weak() {
+		buf.WriteString("weak ")
+	}
+	if inst.Volatile() {
+		buf.WriteString("volatile ")
+	}
+	addr, cmp, new := inst.Addr(), inst.Cmp(), inst.New()
+	fmt.Fprintf(buf, "%s %s, %s %s, %s %s",
+		addr.Type(),
+		addr.Ident(),
+		cmp.Type(),
+		cmp.Ident(),
+		new.Type(),
+		new.Ident())
+	if inst.SingleThread() {
+		buf.WriteString(" singlethread")
+	}
+	fmt.Fprintf(buf, " %s %s", inst.SuccessOrdering(), inst.FailureOrdering())
+	return buf.String()
+}
+
+// Parent returns the parent basic block of the instruction.
+func (inst *InstCmpXchg) Parent() *BasicBlock {
+	return inst.parent
+}
+
+// SetParent sets the parent basic block of the instruction.
+func (inst *InstCmpXchg) SetParent(parent *BasicBlock) {
+	inst.parent = parent
+}
+
+// Addr returns the address to read from, compare against and store to of the
+// cmpxchg instruction.
+func (inst *InstCmpXchg) Addr() value.Value {
+	return inst.addr
+}
+
+// SetAddr sets the address to read from, compare against and store to of the
+// cmpxchg instruction.
+func (inst *InstCmpXchg) SetAddr(addr value.Value) {
+	inst.addr = addr
+}
+
+// Cmp returns the value to compare against the value currently stored at
+// addr.
+func (inst *InstCmpXchg) Cmp() value.Value {
+	return inst.cmp
+}
+
+// SetCmp sets the value to compare against the value currently stored at
+// addr.
+func (inst *InstCmpXchg) SetCmp(cmp value.Value) {
+	inst.cmp = cmp
+}
+
+// New returns the new value to store at addr if the comparison is
+// successful.
+func (inst *InstCmpXchg) New() value.Value {
+	return inst.new
+}
+
+// SetNew sets the new value to store at addr if the comparison is
+// successful.
+func (inst *InstCmpXchg) SetNew(new value.Value) {
+	inst.new = new
+}
+
+// SuccessOrdering returns the atomic memory ordering constraints on success
+// of the cmpxchg instruction.
+func (inst *InstCmpXchg) SuccessOrdering() AtomicOrdering {
+	return inst.successOrdering
+}
+
+// SetSuccessOrdering sets the atomic memory ordering constraints on success
+// of the cmpxchg instruction.
+func (inst *InstCmpXchg) SetSuccessOrdering(successOrdering AtomicOrdering) {
+	inst.successOrdering = successOrdering
+}
+
+// FailureOrdering returns the atomic memory ordering constraints on failure
+// of the cmpxchg instruction.
+func (inst *InstCmpXchg) FailureOrdering() AtomicOrdering {
+	return inst.failureOrdering
+}
+
+// SetFailureOrdering sets the atomic memory ordering constraints on failure
+// of the cmpxchg instruction.
+func (inst *InstCmpXchg) SetFailureOrdering(failureOrdering AtomicOrdering) {
+	inst.failureOrdering = failureOrdering
+}
+
+// SingleThread reports whether the cmpxchg instruction is synchronized with a
+// single thread only, rather than all threads.
+func (inst *InstCmpXchg) SingleThread() bool {
+	return inst.singleThread
+}
+
+// SetSingleThread sets the synchronization scope of the cmpxchg instruction.
+func (inst *InstCmpXchg) SetSingleThread(singleThread bool) {
+	inst.singleThread = singleThread
+}
+
+// Weak reports whether the cmpxchg instruction performs a weak comparison,
+// which may spuriously fail.
+func (inst *InstCmpXchg) Weak() bool {
+	return inst.weak
+}
+
+// SetWeak sets the weak flag of the cmpxchg instruction.
+func (inst *InstCmpXchg) SetWeak(weak bool) {
+	inst.weak = weak
+}
+
+// Volatile reports whether the cmpxchg instruction is a volatile operation.
+func (inst *InstCmpXchg) Volatile() bool {
+	return inst.volatile
+}
+
+// SetVolatile sets the volatile flag of the cmpxchg instruction.
+func (inst *InstCmpXchg) SetVolatile(volatile bool) {
+	inst.volatile = volatile
+}
+
 // --- [ atomicrmw ] -----------------------------------------------------------
 
+// InstAtomicRMW represents an atomicrmw instruction.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#atomicrmw-instruction
+type InstAtomicRMW struct {
+	// Parent basic block.
+	parent *BasicBlock
+	// Name of the local variable associated with the instruction.
+	name string
+	// Atomic operation.
+	op AtomicOp
+	// Address to read from and store to.
+	addr value.Value
+	// Operand value.
+	val value.Value
+	// Atomic memory ordering constraints.
+	ordering AtomicOrdering
+	// Synchronization scope; true if singlethread.
+	singleThread bool
+	// Volatile operation.
+	volatile bool
+}
+
+// NewAtomicRMW returns a new atomicrmw instruction based on the given atomic
+// operation, address, operand value and atomic memory ordering constraints.
+func NewAtomicRMW(op AtomicOp, addr, val value.Value, ordering AtomicOrdering) *InstAtomicRMW {
+	return &InstAtomicRMW{op: op, addr: addr, val: val, ordering: ordering}
+}
+
+// Type returns the type of the instruction.
+func (inst *InstAtomicRMW) Type() types.Type {
+	t, ok := inst.addr.Type().(*types.PointerType)
+	if !ok {
+		panic(fmt.Sprintf("invalid address type; expected *types.PointerType, got %T", inst.addr.Type()))
+	}
+	return t.Elem()
+}
+
+// Ident returns the identifier associated with the instruction.
+func (inst *InstAtomicRMW) Ident() string {
+	return enc.Local(inst.name)
+}
+
+// Name returns the name of the local variable associated with the
+// instruction.
+func (inst *InstAtomicRMW) Name() string {
+	return inst.name
+}
+
+// SetName sets the name of the local variable associated with the
+// instruction.
+func (inst *InstAtomicRMW) SetName(name string) {
+	inst.name = name
+}
+
+// String returns the LLVM syntax representation of the instruction.
+func (inst *InstAtomicRMW) String() string {
+	buf := &bytes.Buffer{}
+	buf.WriteString(inst.Ident())
+	buf.WriteString(" = atomicrmw ")
+	if inst.Volatile() {
+		buf.WriteString("volatile ")
+	}
+	addr, val := inst.Addr(), inst.Val()
+	fmt.Fprintf(buf, "%s %s %s, %s %s",
+		inst.Op(),
+		addr.Type(),
+		addr.Ident(),
+		val.Type(),
+		val.Ident())
+	if inst.SingleThread() {
+		buf.WriteString(" singlethread")
+	}
+	fmt.Fprintf(buf, " %s", inst.Ordering())
+	return buf.String()
+}
+
+// Parent returns the parent basic block of the instruction.
+func (inst *InstAtomicRMW) Parent() *BasicBlock {
+	return inst.parent
+}
+
+// SetParent sets the parent basic block of the instruction.
+func (inst *InstAtomicRMW) SetParent(parent *BasicBlock) {
+	inst.parent = parent
+}
+
+// Op returns the atomic operation of the atomicrmw instruction.
+func (inst *InstAtomicRMW) Op() AtomicOp {
+	return inst.op
+}
+
+// SetOp sets the atomic operation of the atomicrmw instruction.
+func (inst *InstAtomicRMW) SetOp(op AtomicOp) {
+	inst.op = op
+}
+
+// Addr returns the address to read from and store to of the atomicrmw
+// instruction.
+func (inst *InstAtomicRMW) Addr() value.Value {
+	return inst.addr
+}
+
+// SetAddr sets the address to read from and store to of the atomicrmw
+// instruction.
+func (inst *InstAtomicRMW) SetAddr(addr value.Value) {
+	inst.addr = addr
+}
+
+// Val returns the operand value of the atomicrmw instruction.
+func (inst *InstAtomicRMW) Val() value.Value {
+	return inst.val
+}
+
+// SetVal sets the operand value of the atomicrmw instruction.
+func (inst *InstAtomicRMW) SetVal(val value.Value) {
+	inst.val = val
+}
+
+// Ordering returns the atomic memory ordering constraints of the atomicrmw
+// instruction.
+func (inst *InstAtomicRMW) Ordering() AtomicOrdering {
+	return inst.ordering
+}
+
+// SetOrdering sets the atomic memory ordering constraints of the atomicrmw
+// instruction.
+func (inst *InstAtomicRMW) SetOrdering(ordering AtomicOrdering) {
+	inst.ordering = ordering
+}
+
+// SingleThread reports whether the atomicrmw instruction is synchronized with
+// a single thread only, rather than all threads.
+func (inst *InstAtomicRMW) SingleThread() bool {
+	return inst.singleThread
+}
+
+// SetSingleThread sets the synchronization scope of the atomicrmw
+// instruction.
+func (inst *InstAtomicRMW) SetSingleThread(singleThread bool) {
+	inst.singleThread = singleThread
+}
+
+// Volatile reports whether the atomicrmw instruction is a volatile
+// operation.
+func (inst *InstAtomicRMW) Volatile() bool {
+	return inst.volatile
+}
+
+// SetVolatile sets the volatile flag of the atomicrmw instruction.
+func (inst *InstAtomicRMW) SetVolatile(volatile bool) {
+	inst.volatile = volatile
+}
+
 // --- [ getelementptr ] -------------------------------------------------------
 
 // InstGetElementPtr represents a getelementptr instruction.
@@ -0,0 +1,192 @@
+// === [ Other instructions ] =====================================================
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#other-operations
+
+package ir
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/llir/llvm/internal/enc"
+	"github.com/llir/llvm/ir/types"
+	"github.com/llir/llvm/ir/value"
+)
+
+// --- [ phi ] -------------------------------------------------------------
+
+// Incoming represents an incoming value of a phi instruction, associated
+// with the predecessor basic block from which it is sourced.
+type Incoming struct {
+	// Incoming value.
+	X value.Value
+	// Predecessor basic block of the incoming value.
+	Pred *BasicBlock
+}
+
+// InstPhi represents a phi instruction.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#phi-instruction
+type InstPhi struct {
+	parent *BasicBlock
+	name   string
+	typ    types.Type
+	incs   []*Incoming
+}
+
+// NewPhi returns a new phi instruction based on the given type and incoming
+// values.
+func NewPhi(typ types.Type, incs ...*Incoming) *InstPhi {
+	return &InstPhi{typ: typ, incs: incs}
+}
+
+// Type returns the type of the instruction.
+func (inst *InstPhi) Type() types.Type {
+	return inst.typ
+}
+
+// Ident returns the identifier associated with the instruction.
+func (inst *InstPhi) Ident() string {
+	return enc.Local(inst.name)
+}
+
+// Name returns the name of the local variable associated with the
+// instruction.
+func (inst *InstPhi) Name() string {
+	return inst.name
+}
+
+// SetName sets the name of the local variable associated with the
+// instruction.
+func (inst *InstPhi) SetName(name string) {
+	inst.name = name
+}
+
+// Parent returns the parent basic block of the instruction.
+func (inst *InstPhi) Parent() *BasicBlock {
+	return inst.parent
+}
+
+// SetParent sets the parent basic block of the instruction.
+func (inst *InstPhi) SetParent(parent *BasicBlock) {
+	inst.parent = parent
+}
+
+// Incs returns the incoming values of the phi instruction.
+func (inst *InstPhi) Incs() []*Incoming {
+	return inst.incs
+}
+
+// SetIncs sets the incoming values of the phi instruction.
+func (inst *InstPhi) SetIncs(incs []*Incoming) {
+	inst.incs = incs
+}
+
+// String returns the LLVM syntax representation of the instruction.
+func (inst *InstPhi) String() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s = phi %s ", inst.Ident(), inst.Type())
+	for i, inc := range inst.Incs() {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		fmt.Fprintf(buf, "[ %s, %s ]", inc.X.Ident(), inc.Pred.Ident())
+	}
+	return buf.String()
+}
+
+// --- [ call ] -------------------------------------------------------------
+
+// InstCall represents a call instruction.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#call-instruction
+type InstCall struct {
+	parent *BasicBlock
+	name   string
+	callee value.Value
+	args   []value.Value
+}
+
+// NewCall returns a new call instruction based on the given callee and
+// function arguments.
+func NewCall(callee value.Value, args ...value.Value) *InstCall {
+	return &InstCall{callee: callee, args: args}
+}
+
+// Type returns the type of the instruction.
+func (inst *InstCall) Type() types.Type {
+	sig, ok := inst.callee.Type().(*types.FuncType)
+	if !ok {
+		panic(fmt.Sprintf("invalid callee type; expected *types.FuncType, got %T", inst.callee.Type()))
+	}
+	return sig.Ret()
+}
+
+// Ident returns the identifier associated with the instruction.
+func (inst *InstCall) Ident() string {
+	return enc.Local(inst.name)
+}
+
+// Name returns the name of the local variable associated with the
+// instruction.
+func (inst *InstCall) Name() string {
+	return inst.name
+}
+
+// SetName sets the name of the local variable associated with the
+// instruction.
+func (inst *InstCall) SetName(name string) {
+	inst.name = name
+}
+
+// Parent returns the parent basic block of the instruction.
+func (inst *InstCall) Parent() *BasicBlock {
+	return inst.parent
+}
+
+// SetParent sets the parent basic block of the instruction.
+func (inst *InstCall) SetParent(parent *BasicBlock) {
+	inst.parent = parent
+}
+
+// Callee returns the callee of the call instruction.
+func (inst *InstCall) Callee() value.Value {
+	return inst.callee
+}
+
+// SetCallee sets the callee of the call instruction.
+func (inst *InstCall) SetCallee(callee value.Value) {
+	inst.callee = callee
+}
+
+// Args returns the function arguments of the call instruction.
+func (inst *InstCall) Args() []value.Value {
+	return inst.args
+}
+
+// SetArgs sets the function arguments of the call instruction.
+func (inst *InstCall) SetArgs(args []value.Value) {
+	inst.args = args
+}
+
+// String returns the LLVM syntax representation of the instruction.
+func (inst *InstCall) String() string {
+	buf := &bytes.Buffer{}
+	ret := inst.Type()
+	if ret == types.Void {
+		fmt.Fprintf(buf, "call %s %s(", ret, inst.Callee().Ident())
+	} else {
+		fmt.Fprintf(buf, "%s = call %s %s(", inst.Ident(), ret, inst.Callee().Ident())
+	}
+	for i, arg := range inst.Args() {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		fmt.Fprintf(buf, "%s %s", arg.Type(), arg.Ident())
+	}
+	buf.WriteString(")")
+	return buf.String()
+}
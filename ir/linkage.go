@@ -0,0 +1,49 @@
+// === [ Linkage types ] ========================================================
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#linkage-types
+
+package ir
+
+import "fmt"
+
+// Linkage specifies the linkage type of a global identifier.
+type Linkage int
+
+// Linkage types.
+const (
+	// LinkageNone specifies no linkage type, the default linkage for function
+	// definitions, global variable definitions, and aliases.
+	LinkageNone Linkage = iota
+	LinkagePrivate
+	LinkageInternal
+	LinkageExternal
+	LinkageLinkOnce
+	LinkageLinkOnceODR
+	LinkageWeak
+	LinkageWeakODR
+	LinkageAppending
+	LinkageCommon
+	LinkageAvailableExternally
+)
+
+// String returns the LLVM syntax representation of the linkage type.
+func (linkage Linkage) String() string {
+	m := map[Linkage]string{
+		LinkageNone:                "",
+		LinkagePrivate:             "private",
+		LinkageInternal:            "internal",
+		LinkageExternal:            "external",
+		LinkageLinkOnce:            "linkonce",
+		LinkageLinkOnceODR:         "linkonce_odr",
+		LinkageWeak:                "weak",
+		LinkageWeakODR:             "weak_odr",
+		LinkageAppending:           "appending",
+		LinkageCommon:              "common",
+		LinkageAvailableExternally: "available_externally",
+	}
+	if s, ok := m[linkage]; ok {
+		return s
+	}
+	panic(fmt.Sprintf("support for linkage type %d not yet implemented", int(linkage)))
+}
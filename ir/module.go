@@ -0,0 +1,103 @@
+// === [ Modules ] ===============================================================
+
+package ir
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Module represents an LLVM IR module, the top-level container of global
+// identifiers.
+type Module struct {
+	// Functions of the module.
+	funcs []*Function
+	// Attribute groups of the module.
+	attrGroups []*AttrGroup
+}
+
+// NewModule returns a new empty module.
+func NewModule() *Module {
+	return &Module{}
+}
+
+// Funcs returns the functions of the module.
+func (m *Module) Funcs() []*Function {
+	return m.funcs
+}
+
+// AppendFunc appends the given function to the module.
+func (m *Module) AppendFunc(f *Function) {
+	m.funcs = append(m.funcs, f)
+}
+
+// AttrGroups returns the attribute groups of the module.
+func (m *Module) AttrGroups() []*AttrGroup {
+	return m.attrGroups
+}
+
+// NewAttrGroup returns a new attribute group, with a unique ID, appended to
+// the module.
+func (m *Module) NewAttrGroup() *AttrGroup {
+	group := &AttrGroup{id: AttrGroupID(len(m.attrGroups))}
+	m.attrGroups = append(m.attrGroups, group)
+	return group
+}
+
+// String returns the LLVM syntax representation of the module.
+func (m *Module) String() string {
+	buf := &bytes.Buffer{}
+	for i, f := range m.Funcs() {
+		if i > 0 {
+			buf.WriteString("\n\n")
+		}
+		fmt.Fprint(buf, f)
+	}
+	for _, group := range m.AttrGroups() {
+		if buf.Len() > 0 {
+			buf.WriteString("\n\n")
+		}
+		fmt.Fprint(buf, group)
+	}
+	return buf.String()
+}
+
+// AttrGroup represents a named group of function attributes, defined
+// separately from the functions that reference it (through AttrGroupID) so
+// that the same set of attributes may be shared by multiple functions.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#attribute-groups
+type AttrGroup struct {
+	// Attribute group ID.
+	id AttrGroupID
+	// Function attributes of the attribute group.
+	attrs []FuncAttr
+}
+
+// ID returns the ID of the attribute group.
+func (group *AttrGroup) ID() AttrGroupID {
+	return group.id
+}
+
+// Attrs returns the function attributes of the attribute group.
+func (group *AttrGroup) Attrs() []FuncAttr {
+	return group.attrs
+}
+
+// SetAttrs sets the function attributes of the attribute group.
+func (group *AttrGroup) SetAttrs(attrs []FuncAttr) {
+	group.attrs = attrs
+}
+
+// String returns the LLVM syntax representation of the attribute group
+// definition.
+func (group *AttrGroup) String() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "attributes %s = {", group.ID())
+	for _, attr := range group.Attrs() {
+		fmt.Fprintf(buf, " %s", attr)
+	}
+	buf.WriteString(" }")
+	return buf.String()
+}
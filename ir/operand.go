@@ -0,0 +1,165 @@
+// operand.go provides a generic view of instruction and terminator operands.
+// BasicBlock's Uses and ReplaceAllUsesWith, and callers outside the ir
+// package such as the mem2reg and SimplifyCFG passes, build on top of these
+// so that adding a new instruction type only ever requires updating the
+// switches below.
+
+package ir
+
+import "github.com/llir/llvm/ir/value"
+
+// Operands returns the value operands of inst.
+func Operands(inst Instruction) []value.Value {
+	switch v := inst.(type) {
+	case *InstAlloca:
+		if n, ok := v.NElems(); ok {
+			return []value.Value{n}
+		}
+	case *InstLoad:
+		return []value.Value{v.Src()}
+	case *InstStore:
+		return []value.Value{v.Src(), v.Dst()}
+	case *InstCmpXchg:
+		return []value.Value{v.Addr(), v.Cmp(), v.New()}
+	case *InstAtomicRMW:
+		return []value.Value{v.Addr(), v.Val()}
+	case *InstGetElementPtr:
+		return append([]value.Value{v.Src()}, v.Indices()...)
+	case *InstBinOp:
+		return []value.Value{v.X(), v.Y()}
+	case *InstICmp:
+		return []value.Value{v.X(), v.Y()}
+	case *InstFCmp:
+		return []value.Value{v.X(), v.Y()}
+	case *InstConv:
+		return []value.Value{v.From()}
+	case *InstCall:
+		return append([]value.Value{v.Callee()}, v.Args()...)
+	case *InstPhi:
+		var ops []value.Value
+		for _, inc := range v.Incs() {
+			ops = append(ops, inc.X)
+		}
+		return ops
+	}
+	return nil
+}
+
+// ReplaceOperand replaces every operand of inst that equals old with new.
+func ReplaceOperand(inst Instruction, old, new value.Value) {
+	switch v := inst.(type) {
+	case *InstAlloca:
+		if n, ok := v.NElems(); ok && n == old {
+			v.SetNElems(new)
+		}
+	case *InstLoad:
+		if v.Src() == old {
+			v.SetSrc(new)
+		}
+	case *InstStore:
+		if v.Src() == old {
+			v.SetSrc(new)
+		}
+		if v.Dst() == old {
+			v.SetDst(new)
+		}
+	case *InstCmpXchg:
+		if v.Addr() == old {
+			v.SetAddr(new)
+		}
+		if v.Cmp() == old {
+			v.SetCmp(new)
+		}
+		if v.New() == old {
+			v.SetNew(new)
+		}
+	case *InstAtomicRMW:
+		if v.Addr() == old {
+			v.SetAddr(new)
+		}
+		if v.Val() == old {
+			v.SetVal(new)
+		}
+	case *InstGetElementPtr:
+		if v.Src() == old {
+			v.SetSrc(new)
+		}
+		indices := v.Indices()
+		for i, idx := range indices {
+			if idx == old {
+				indices[i] = new
+			}
+		}
+		v.SetIndices(indices)
+	case *InstBinOp:
+		if v.X() == old {
+			v.SetX(new)
+		}
+		if v.Y() == old {
+			v.SetY(new)
+		}
+	case *InstICmp:
+		if v.X() == old {
+			v.SetX(new)
+		}
+		if v.Y() == old {
+			v.SetY(new)
+		}
+	case *InstFCmp:
+		if v.X() == old {
+			v.SetX(new)
+		}
+		if v.Y() == old {
+			v.SetY(new)
+		}
+	case *InstConv:
+		if v.From() == old {
+			v.SetFrom(new)
+		}
+	case *InstCall:
+		if v.Callee() == old {
+			v.SetCallee(new)
+		}
+		args := v.Args()
+		for i, arg := range args {
+			if arg == old {
+				args[i] = new
+			}
+		}
+		v.SetArgs(args)
+	case *InstPhi:
+		for _, inc := range v.Incs() {
+			if inc.X == old {
+				inc.X = new
+			}
+		}
+	}
+}
+
+// TermOperands returns the value operands of term.
+func TermOperands(term Terminator) []value.Value {
+	switch t := term.(type) {
+	case *TermRet:
+		if x, ok := t.X(); ok {
+			return []value.Value{x}
+		}
+	case *TermCondBr:
+		return []value.Value{t.Cond()}
+	}
+	return nil
+}
+
+// ReplaceTermOperand replaces every operand of term that equals old with
+// new.
+func ReplaceTermOperand(term Terminator, old, new value.Value) {
+	switch t := term.(type) {
+	case *TermRet:
+		if x, ok := t.X(); ok && x == old {
+			t.SetX(new)
+		}
+	case *TermCondBr:
+		if t.Cond() == old {
+			t.SetCond(new)
+		}
+	}
+}
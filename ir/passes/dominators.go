@@ -0,0 +1,197 @@
+// Package passes implements IR-level analyses and transformations operating
+// on ir.Function (dominance, mem2reg, CFG simplification).
+package passes
+
+import "github.com/llir/llvm/ir"
+
+// DomTree represents the dominator tree of a function, together with the
+// dominance frontier of each basic block.
+//
+// References:
+//    Cooper, Harvey, Kennedy - A Simple, Fast Dominance Algorithm.
+type DomTree struct {
+	// Reverse postorder of the basic blocks reachable from the entry block;
+	// order[0] is the entry block.
+	order []*ir.BasicBlock
+	// Position of each basic block within order.
+	index map[*ir.BasicBlock]int
+	// Immediate dominator of each basic block.
+	idom map[*ir.BasicBlock]*ir.BasicBlock
+	// Dominance frontier of each basic block.
+	frontier map[*ir.BasicBlock][]*ir.BasicBlock
+	// Children of each basic block in the dominator tree.
+	children map[*ir.BasicBlock][]*ir.BasicBlock
+	preds    map[*ir.BasicBlock][]*ir.BasicBlock
+	succs    map[*ir.BasicBlock][]*ir.BasicBlock
+}
+
+// Dominators computes the dominator tree and dominance frontier of the given
+// function.
+func Dominators(f *ir.Function) *DomTree {
+	blocks := f.Blocks()
+	t := &DomTree{
+		idom:     make(map[*ir.BasicBlock]*ir.BasicBlock),
+		frontier: make(map[*ir.BasicBlock][]*ir.BasicBlock),
+		children: make(map[*ir.BasicBlock][]*ir.BasicBlock),
+	}
+	if len(blocks) == 0 {
+		return t
+	}
+	entry := blocks[0]
+	t.succs = computeSuccs(f, blocks)
+	t.preds = computePreds(blocks, t.succs)
+	t.order = reversePostorder(entry, t.succs)
+	t.index = make(map[*ir.BasicBlock]int, len(t.order))
+	for i, bb := range t.order {
+		t.index[bb] = i
+	}
+
+	idom := make([]*ir.BasicBlock, len(t.order))
+	idom[0] = entry
+	for changed := true; changed; {
+		changed = false
+		for i := 1; i < len(t.order); i++ {
+			bb := t.order[i]
+			var newIdom *ir.BasicBlock
+			for _, p := range t.preds[bb] {
+				pi, ok := t.index[p]
+				if !ok || idom[pi] == nil {
+					// Predecessor unreachable from entry, or not yet
+					// processed in this iteration.
+					continue
+				}
+				if newIdom == nil {
+					newIdom = p
+					continue
+				}
+				newIdom = intersect(idom, t.index, newIdom, p)
+			}
+			if newIdom != idom[i] {
+				idom[i] = newIdom
+				changed = true
+			}
+		}
+	}
+	for i, bb := range t.order {
+		if i == 0 {
+			continue
+		}
+		t.idom[bb] = idom[i]
+		t.children[idom[i]] = append(t.children[idom[i]], bb)
+	}
+
+	// Compute the dominance frontier: for each basic block with two or more
+	// predecessors, walk each predecessor up the dominator tree until the
+	// block's immediate dominator is reached.
+	for _, bb := range t.order {
+		if len(t.preds[bb]) < 2 {
+			continue
+		}
+		for _, p := range t.preds[bb] {
+			if _, ok := t.index[p]; !ok {
+				continue
+			}
+			runner := p
+			for runner != t.idom[bb] && runner != nil {
+				t.frontier[runner] = append(t.frontier[runner], bb)
+				runner = t.idom[runner]
+			}
+		}
+	}
+	return t
+}
+
+// Entry returns the entry basic block of the dominator tree.
+func (t *DomTree) Entry() *ir.BasicBlock {
+	if len(t.order) == 0 {
+		return nil
+	}
+	return t.order[0]
+}
+
+// IDom returns the immediate dominator of the given basic block, or nil if
+// bb is the entry block or unreachable.
+func (t *DomTree) IDom(bb *ir.BasicBlock) *ir.BasicBlock {
+	return t.idom[bb]
+}
+
+// Children returns the children of the given basic block in the dominator
+// tree.
+func (t *DomTree) Children(bb *ir.BasicBlock) []*ir.BasicBlock {
+	return t.children[bb]
+}
+
+// Frontier returns the dominance frontier of the given basic block.
+func (t *DomTree) Frontier(bb *ir.BasicBlock) []*ir.BasicBlock {
+	return t.frontier[bb]
+}
+
+// Dominates reports whether a dominates b.
+func (t *DomTree) Dominates(a, b *ir.BasicBlock) bool {
+	for cur := b; cur != nil; cur = t.idom[cur] {
+		if cur == a {
+			return true
+		}
+	}
+	return false
+}
+
+// intersect returns the closest common dominator of b1 and b2, walking the
+// partially constructed dominator tree towards the entry block.
+func intersect(idom []*ir.BasicBlock, index map[*ir.BasicBlock]int, b1, b2 *ir.BasicBlock) *ir.BasicBlock {
+	i1, i2 := index[b1], index[b2]
+	for i1 != i2 {
+		for i1 > i2 {
+			b1 = idom[i1]
+			i1 = index[b1]
+		}
+		for i2 > i1 {
+			b2 = idom[i2]
+			i2 = index[b2]
+		}
+	}
+	return b1
+}
+
+// reversePostorder returns the basic blocks reachable from entry in reverse
+// postorder.
+func reversePostorder(entry *ir.BasicBlock, succs map[*ir.BasicBlock][]*ir.BasicBlock) []*ir.BasicBlock {
+	var postorder []*ir.BasicBlock
+	visited := make(map[*ir.BasicBlock]bool)
+	var visit func(bb *ir.BasicBlock)
+	visit = func(bb *ir.BasicBlock) {
+		visited[bb] = true
+		for _, succ := range succs[bb] {
+			if !visited[succ] {
+				visit(succ)
+			}
+		}
+		postorder = append(postorder, bb)
+	}
+	visit(entry)
+	order := make([]*ir.BasicBlock, len(postorder))
+	for i, bb := range postorder {
+		order[len(postorder)-1-i] = bb
+	}
+	return order
+}
+
+// computeSuccs returns the successor basic blocks of every block in blocks,
+// as determined by ir.Function.Succs.
+func computeSuccs(f *ir.Function, blocks []*ir.BasicBlock) map[*ir.BasicBlock][]*ir.BasicBlock {
+	succs := make(map[*ir.BasicBlock][]*ir.BasicBlock, len(blocks))
+	for _, bb := range blocks {
+		succs[bb] = f.Succs(bb)
+	}
+	return succs
+}
+
+func computePreds(blocks []*ir.BasicBlock, succs map[*ir.BasicBlock][]*ir.BasicBlock) map[*ir.BasicBlock][]*ir.BasicBlock {
+	preds := make(map[*ir.BasicBlock][]*ir.BasicBlock, len(blocks))
+	for _, bb := range blocks {
+		for _, succ := range succs[bb] {
+			preds[succ] = append(preds[succ], bb)
+		}
+	}
+	return preds
+}
@@ -0,0 +1,243 @@
+// mem2reg.go implements the classic Cytron et al. algorithm for promoting
+// stack-allocated locals to SSA registers.
+
+package passes
+
+import (
+	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/constant"
+	"github.com/llir/llvm/ir/value"
+)
+
+// PromoteMemoryToRegisters lifts allocas of f that are only ever accessed
+// through non-aliased, non-volatile load and store instructions into SSA
+// registers, inserting phi nodes at the iterated dominance frontier of their
+// defining blocks.
+func PromoteMemoryToRegisters(f *ir.Function) {
+	blocks := f.Blocks()
+	if len(blocks) == 0 {
+		return
+	}
+	allocas := promotableAllocas(f)
+	if len(allocas) == 0 {
+		return
+	}
+	domTree := Dominators(f)
+
+	// defBlocks[a] is the set of blocks containing a store to a.
+	defBlocks := make(map[*ir.InstAlloca][]*ir.BasicBlock)
+	for _, bb := range blocks {
+		for _, inst := range bb.Insts() {
+			if store, ok := inst.(*ir.InstStore); ok {
+				if a, ok := allocas[store.Dst()]; ok {
+					defBlocks[a] = append(defBlocks[a], bb)
+				}
+			}
+		}
+	}
+
+	// phis[bb][a] is the phi instruction placed in bb to merge the incoming
+	// values of alloca a.
+	phis := make(map[*ir.BasicBlock]map[*ir.InstAlloca]*ir.InstPhi)
+	placePhi := func(bb *ir.BasicBlock, a *ir.InstAlloca) *ir.InstPhi {
+		if m, ok := phis[bb]; ok {
+			if phi, ok := m[a]; ok {
+				return phi
+			}
+		} else {
+			phis[bb] = make(map[*ir.InstAlloca]*ir.InstPhi)
+		}
+		var incs []*ir.Incoming
+		for _, p := range domTree.preds[bb] {
+			if _, ok := domTree.index[p]; !ok {
+				// Predecessor unreachable from entry; rename never visits
+				// it, so it can never supply an incoming value.
+				continue
+			}
+			incs = append(incs, &ir.Incoming{Pred: p})
+		}
+		phi := ir.NewPhi(a.ElemType(), incs...)
+		phis[bb][a] = phi
+		return phi
+	}
+
+	for a, defs := range defBlocks {
+		hasPhi := make(map[*ir.BasicBlock]bool)
+		worklist := append([]*ir.BasicBlock{}, defs...)
+		for len(worklist) > 0 {
+			bb := worklist[len(worklist)-1]
+			worklist = worklist[:len(worklist)-1]
+			for _, df := range domTree.Frontier(bb) {
+				if hasPhi[df] {
+					continue
+				}
+				hasPhi[df] = true
+				placePhi(df, a)
+				worklist = append(worklist, df)
+			}
+		}
+	}
+
+	// replacements maps a promoted load instruction to the value that
+	// replaces its uses.
+	replacements := make(map[value.Value]value.Value)
+	stacks := make(map[*ir.InstAlloca][]value.Value)
+	push := func(a *ir.InstAlloca, v value.Value) {
+		stacks[a] = append(stacks[a], v)
+	}
+	top := func(a *ir.InstAlloca) value.Value {
+		s := stacks[a]
+		if len(s) == 0 {
+			// Read of a local before any store dominates it; the local is
+			// undefined at this point, so substitute LLVM's undef value.
+			return constant.NewUndef(a.ElemType())
+		}
+		return s[len(s)-1]
+	}
+
+	var rename func(bb *ir.BasicBlock)
+	rename = func(bb *ir.BasicBlock) {
+		pushed := make(map[*ir.InstAlloca]int)
+		for a, phi := range phis[bb] {
+			push(a, phi)
+			pushed[a]++
+		}
+
+		var newInsts []ir.Instruction
+		for _, inst := range bb.Insts() {
+			switch v := inst.(type) {
+			case *ir.InstAlloca:
+				if _, ok := allocas[v]; ok {
+					continue
+				}
+			case *ir.InstLoad:
+				if a, ok := allocas[v.Src()]; ok {
+					replacements[v] = top(a)
+					continue
+				}
+			case *ir.InstStore:
+				if a, ok := allocas[v.Dst()]; ok {
+					src := v.Src()
+					if repl, ok := replacements[src]; ok {
+						// The stored value is itself a promoted load.
+						src = repl
+					}
+					push(a, src)
+					pushed[a]++
+					continue
+				}
+			}
+			newInsts = append(newInsts, inst)
+		}
+
+		var frontPhis []ir.Instruction
+		for _, phi := range phis[bb] {
+			phi.SetParent(bb)
+			frontPhis = append(frontPhis, phi)
+		}
+		bb.SetInsts(append(frontPhis, newInsts...))
+
+		for _, succ := range domTree.succs[bb] {
+			for a, phi := range phis[succ] {
+				for _, inc := range phi.Incs() {
+					if inc.Pred == bb {
+						inc.X = top(a)
+					}
+				}
+			}
+		}
+
+		for _, child := range domTree.Children(bb) {
+			rename(child)
+		}
+
+		for a, n := range pushed {
+			stacks[a] = stacks[a][:len(stacks[a])-n]
+		}
+	}
+	rename(domTree.Entry())
+
+	rewriteOperands(f, replacements)
+}
+
+// promotableAllocas returns the set of allocas in f whose only uses are
+// direct loads from and stores to the alloca; that is, the address of the
+// alloca is never taken. The type of a stored value is not compared against
+// the alloca's element type.
+func promotableAllocas(f *ir.Function) map[value.Value]*ir.InstAlloca {
+	allocas := make(map[value.Value]*ir.InstAlloca)
+	for _, bb := range f.Blocks() {
+		for _, inst := range bb.Insts() {
+			if a, ok := inst.(*ir.InstAlloca); ok {
+				if _, ok := a.NElems(); !ok {
+					allocas[a] = a
+				}
+			}
+		}
+	}
+	if len(allocas) == 0 {
+		return allocas
+	}
+	escapes := make(map[value.Value]bool)
+	for _, bb := range f.Blocks() {
+		for _, inst := range bb.Insts() {
+			for _, op := range operandsEscaping(inst) {
+				if _, ok := allocas[op]; ok {
+					escapes[op] = true
+				}
+			}
+		}
+		for _, op := range ir.TermOperands(bb.Term()) {
+			if _, ok := allocas[op]; ok {
+				escapes[op] = true
+			}
+		}
+	}
+	for a := range escapes {
+		delete(allocas, a)
+	}
+	return allocas
+}
+
+// operandsEscaping returns the operands of inst that, if an alloca, indicate
+// that its address has escaped beyond direct load/store access. Loads read
+// their source and stores write their destination without exposing the
+// address itself, so those two positions are intentionally excluded; every
+// other instruction type defers to ir.Operands, the single canonical list of
+// an instruction's value operands.
+func operandsEscaping(inst ir.Instruction) []value.Value {
+	switch v := inst.(type) {
+	case *ir.InstLoad:
+		return nil
+	case *ir.InstStore:
+		// The value being stored may itself be the address of another
+		// alloca; that use does escape.
+		return []value.Value{v.Src()}
+	}
+	return ir.Operands(inst)
+}
+
+// rewriteOperands substitutes every operand present in replacements
+// throughout the function with its mapped value, using ir.Operands /
+// ir.ReplaceOperand so that every instruction type is handled in exactly one
+// place.
+func rewriteOperands(f *ir.Function, replacements map[value.Value]value.Value) {
+	if len(replacements) == 0 {
+		return
+	}
+	for _, bb := range f.Blocks() {
+		for _, inst := range bb.Insts() {
+			for _, op := range ir.Operands(inst) {
+				if repl, ok := replacements[op]; ok {
+					ir.ReplaceOperand(inst, op, repl)
+				}
+			}
+		}
+		term := bb.Term()
+		for _, op := range ir.TermOperands(term) {
+			if repl, ok := replacements[op]; ok {
+				ir.ReplaceTermOperand(term, op, repl)
+			}
+		}
+	}
+}
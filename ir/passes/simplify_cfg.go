@@ -0,0 +1,317 @@
+// simplify_cfg.go implements dead-block elimination and straight-line block
+// merging for ir.Function.
+
+package passes
+
+import (
+	"fmt"
+
+	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/constant"
+	"github.com/llir/llvm/ir/value"
+)
+
+// SimplifyCFG simplifies the control flow graph of f: unreachable blocks are
+// removed, a block with a single predecessor ending in an unconditional
+// branch to it is merged into that predecessor, empty blocks containing only
+// an unconditional branch are elided, and conditional branches on a constant
+// condition are folded into unconditional branches.
+func SimplifyCFG(f *ir.Function) {
+	if len(f.Blocks()) == 0 {
+		return
+	}
+	foldConstantBranches(f)
+	removeUnreachableBlocks(f)
+	mergeBlocks(f)
+	removeEmptyBlocks(f)
+	f.AssignIDs()
+}
+
+// foldConstantBranches rewrites `br i1 true/false, label %a, label %b`
+// terminators into unconditional branches.
+func foldConstantBranches(f *ir.Function) {
+	for _, bb := range f.Blocks() {
+		condBr, ok := bb.Term().(*ir.TermCondBr)
+		if !ok {
+			continue
+		}
+		ci, ok := condBr.Cond().(*constant.Int)
+		if !ok {
+			continue
+		}
+		live, dead := condBr.TargetFalse(), condBr.TargetTrue()
+		if ci.Int64() != 0 {
+			live, dead = condBr.TargetTrue(), condBr.TargetFalse()
+		}
+		if live != dead {
+			removeIncomingFromPhis(dead, bb)
+		}
+		bb.SetTerm(ir.NewBr(live))
+	}
+}
+
+// removeUnreachableBlocks removes every basic block not reachable from the
+// entry block, fixing up the phi incoming lists of their surviving
+// successors.
+func removeUnreachableBlocks(f *ir.Function) {
+	blocks := f.Blocks()
+	entry := blocks[0]
+	succs := computeSuccs(f, blocks)
+	reachable := make(map[*ir.BasicBlock]bool)
+	var visit func(bb *ir.BasicBlock)
+	visit = func(bb *ir.BasicBlock) {
+		if reachable[bb] {
+			return
+		}
+		reachable[bb] = true
+		for _, s := range succs[bb] {
+			visit(s)
+		}
+	}
+	visit(entry)
+	// Iterate over a copy, since RemoveBlock shifts f's block slice in place.
+	for _, bb := range append([]*ir.BasicBlock(nil), blocks...) {
+		if reachable[bb] {
+			continue
+		}
+		for _, s := range succs[bb] {
+			if reachable[s] {
+				removeIncomingFromPhis(s, bb)
+			}
+		}
+		f.RemoveBlock(bb)
+	}
+}
+
+// mergeBlocks merges a block into its unique predecessor whenever that
+// predecessor's terminator is an unconditional branch to it.
+func mergeBlocks(f *ir.Function) {
+	for {
+		blocks := f.Blocks()
+		succs := computeSuccs(f, blocks)
+		preds := computePreds(blocks, succs)
+		merged := false
+		for _, p := range blocks {
+			br, ok := p.Term().(*ir.TermBr)
+			if !ok {
+				continue
+			}
+			b := br.Target()
+			if b == p || len(preds[b]) != 1 {
+				continue
+			}
+			mergeBlockInto(f, p, b, succs)
+			merged = true
+			break
+		}
+		if !merged {
+			return
+		}
+	}
+}
+
+// mergeBlockInto concatenates the instructions of b onto the end of p,
+// adopts b's terminator as p's own, and rewrites b's successors so that
+// their phi instructions reference p instead of b.
+func mergeBlockInto(f *ir.Function, p, b *ir.BasicBlock, succs map[*ir.BasicBlock][]*ir.BasicBlock) {
+	replacements := make(map[value.Value]value.Value)
+	var carried []ir.Instruction
+	for _, inst := range b.Insts() {
+		if phi, ok := inst.(*ir.InstPhi); ok && len(phi.Incs()) == 1 {
+			// b has a single predecessor, so any phi it contains has
+			// exactly one incoming value; replace its uses directly.
+			replacements[phi] = phi.Incs()[0].X
+			continue
+		}
+		inst.SetParent(p)
+		carried = append(carried, inst)
+	}
+	p.SetInsts(append(p.Insts(), carried...))
+	p.SetTerm(b.Term())
+
+	for _, succ := range succs[b] {
+		for _, inst := range succ.Insts() {
+			phi, ok := inst.(*ir.InstPhi)
+			if !ok {
+				continue
+			}
+			for _, inc := range phi.Incs() {
+				if inc.Pred == b {
+					inc.Pred = p
+				}
+			}
+		}
+	}
+
+	rewriteOperands(f, replacements)
+	f.RemoveBlock(b)
+}
+
+// removeEmptyBlocks elides blocks whose only content is an unconditional
+// branch, redirecting their predecessors straight to the target. Elision is
+// skipped when the target has phi instructions, since collapsing multiple
+// predecessors into one would otherwise make the incoming list ambiguous.
+func removeEmptyBlocks(f *ir.Function) {
+	for {
+		blocks := f.Blocks()
+		entry := blocks[0]
+		preds := computePreds(blocks, computeSuccs(f, blocks))
+		removed := false
+		for _, bb := range blocks {
+			if bb == entry || len(bb.Insts()) != 0 {
+				continue
+			}
+			br, ok := bb.Term().(*ir.TermBr)
+			if !ok {
+				continue
+			}
+			target := br.Target()
+			if target == bb || hasPhis(target) {
+				continue
+			}
+			for _, p := range preds[bb] {
+				redirectTerminator(p, bb, target)
+			}
+			f.RemoveBlock(bb)
+			removed = true
+			break
+		}
+		if !removed {
+			return
+		}
+	}
+}
+
+// hasPhis reports whether bb begins with one or more phi instructions.
+func hasPhis(bb *ir.BasicBlock) bool {
+	for _, inst := range bb.Insts() {
+		if _, ok := inst.(*ir.InstPhi); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// redirectTerminator rewrites any branch target of from into to, on the
+// terminator of p.
+func redirectTerminator(p, from, to *ir.BasicBlock) {
+	switch t := p.Term().(type) {
+	case *ir.TermBr:
+		if t.Target() == from {
+			t.SetTarget(to)
+		}
+	case *ir.TermCondBr:
+		if t.TargetTrue() == from {
+			t.SetTargetTrue(to)
+		}
+		if t.TargetFalse() == from {
+			t.SetTargetFalse(to)
+		}
+	}
+}
+
+// removeIncomingFromPhis drops the incoming value sourced from pred out of
+// every phi instruction at the start of bb.
+func removeIncomingFromPhis(bb, pred *ir.BasicBlock) {
+	for _, inst := range bb.Insts() {
+		phi, ok := inst.(*ir.InstPhi)
+		if !ok {
+			continue
+		}
+		var incs []*ir.Incoming
+		for _, inc := range phi.Incs() {
+			if inc.Pred != pred {
+				incs = append(incs, inc)
+			}
+		}
+		phi.SetIncs(incs)
+	}
+}
+
+// SanityCheck verifies structural invariants of f: every basic block ends in
+// exactly one terminator, phi predecessor lists match the actual CFG, and
+// every instruction operand is defined in a block that dominates its use.
+func SanityCheck(f *ir.Function) error {
+	blocks := f.Blocks()
+	if len(blocks) == 0 {
+		return nil
+	}
+	for _, bb := range blocks {
+		if bb.Term() == nil {
+			return fmt.Errorf("basic block %q has no terminator", bb.Name())
+		}
+	}
+
+	succs := computeSuccs(f, blocks)
+	preds := computePreds(blocks, succs)
+	for _, bb := range blocks {
+		want := make(map[*ir.BasicBlock]bool, len(preds[bb]))
+		for _, p := range preds[bb] {
+			want[p] = true
+		}
+		for _, inst := range bb.Insts() {
+			phi, ok := inst.(*ir.InstPhi)
+			if !ok {
+				continue
+			}
+			seen := make(map[*ir.BasicBlock]bool, len(phi.Incs()))
+			for _, inc := range phi.Incs() {
+				if !want[inc.Pred] {
+					return fmt.Errorf("phi %s in block %q references non-predecessor block %q", phi.Ident(), bb.Name(), inc.Pred.Name())
+				}
+				seen[inc.Pred] = true
+			}
+			if len(seen) != len(want) {
+				return fmt.Errorf("phi %s in block %q does not cover all predecessor blocks", phi.Ident(), bb.Name())
+			}
+		}
+	}
+
+	domTree := Dominators(f)
+	defBlock := make(map[value.Value]*ir.BasicBlock)
+	for _, bb := range blocks {
+		for _, inst := range bb.Insts() {
+			if v, ok := inst.(value.Value); ok {
+				defBlock[v] = bb
+			}
+		}
+	}
+	checkDominates := func(useBlock *ir.BasicBlock, v value.Value) error {
+		db, ok := defBlock[v]
+		if !ok || db == useBlock {
+			// Constant, parameter, global, or intra-block use; not checked
+			// here since it requires instruction-order tracking.
+			return nil
+		}
+		if !domTree.Dominates(db, useBlock) {
+			return fmt.Errorf("value %s defined in block %q does not dominate its use in block %q", v.Ident(), db.Name(), useBlock.Name())
+		}
+		return nil
+	}
+	for _, bb := range blocks {
+		for _, inst := range bb.Insts() {
+			if phi, ok := inst.(*ir.InstPhi); ok {
+				// Each phi incoming value must dominate the end of its
+				// corresponding predecessor block, not the phi's own block
+				// (which the predecessor-list check above already covers).
+				for _, inc := range phi.Incs() {
+					if err := checkDominates(inc.Pred, inc.X); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+			for _, op := range ir.Operands(inst) {
+				if err := checkDominates(bb, op); err != nil {
+					return err
+				}
+			}
+		}
+		for _, op := range ir.TermOperands(bb.Term()) {
+			if err := checkDominates(bb, op); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
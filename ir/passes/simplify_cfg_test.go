@@ -0,0 +1,263 @@
+package passes
+
+import (
+	"testing"
+
+	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/constant"
+	"github.com/llir/llvm/ir/types"
+)
+
+// TestRemoveUnreachableBlocksDropsDeadBlockAndFixesPhis builds a function
+// containing a block unreachable from entry that nonetheless branches into a
+// live block, and checks that removeUnreachableBlocks deletes the dead block
+// and drops its now-stale incoming value from the live block's phi.
+func TestRemoveUnreachableBlocksDropsDeadBlockAndFixesPhis(t *testing.T) {
+	i32 := types.NewInt(32)
+	f := ir.NewFunction("f", i32)
+
+	entry := ir.NewBasicBlock("entry")
+	dead := ir.NewBasicBlock("dead")
+	live := ir.NewBasicBlock("live")
+	f.AppendBlock(entry)
+	f.AppendBlock(dead)
+	f.AppendBlock(live)
+
+	entry.SetTerm(ir.NewBr(live))
+	dead.SetTerm(ir.NewBr(live))
+
+	phi := ir.NewPhi(i32,
+		&ir.Incoming{X: constant.NewInt(i32, 1), Pred: entry},
+		&ir.Incoming{X: constant.NewInt(i32, 2), Pred: dead},
+	)
+	phi.SetParent(live)
+	live.AppendInst(phi)
+	live.SetTerm(ir.NewRet(phi))
+
+	removeUnreachableBlocks(f)
+	f.AssignIDs()
+
+	if err := SanityCheck(f); err != nil {
+		t.Fatalf("SanityCheck failed after removing unreachable blocks: %v", err)
+	}
+
+	want := `define i32 @f() {
+entry:
+	br label %live
+live:
+	%0 = phi i32 [ 1, %entry ]
+	ret i32 %0
+}`
+	if got := f.String(); got != want {
+		t.Errorf("removeUnreachableBlocks mismatch;\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// TestMergeBlocksFoldsUniqueSuccessorIntoPredecessor builds a straight-line
+// function where the entry block unconditionally branches to a block with no
+// other predecessor, and checks that mergeBlocks folds the two into one.
+func TestMergeBlocksFoldsUniqueSuccessorIntoPredecessor(t *testing.T) {
+	i32 := types.NewInt(32)
+	f := ir.NewFunction("f", i32)
+
+	entry := ir.NewBasicBlock("entry")
+	mid := ir.NewBasicBlock("mid")
+	end := ir.NewBasicBlock("end")
+	f.AppendBlock(entry)
+	f.AppendBlock(mid)
+	f.AppendBlock(end)
+
+	b := ir.NewBuilder()
+	b.SetInsertPoint(entry)
+	b.EmitBr(mid)
+
+	b.SetInsertPoint(mid)
+	v := b.EmitBinOp(ir.BinOpAdd, constant.NewInt(i32, 1), constant.NewInt(i32, 1))
+	b.EmitBr(end)
+
+	b.SetInsertPoint(end)
+	b.EmitRet(v)
+
+	mergeBlocks(f)
+	f.AssignIDs()
+
+	if err := SanityCheck(f); err != nil {
+		t.Fatalf("SanityCheck failed after merging blocks: %v", err)
+	}
+
+	want := `define i32 @f() {
+entry:
+	%0 = add i32 1, 1
+	br label %end
+end:
+	ret i32 %0
+}`
+	if got := f.String(); got != want {
+		t.Errorf("mergeBlocks mismatch;\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// TestRemoveEmptyBlocksElidesUnconditionalTrampoline builds a diamond whose
+// two arms both jump through an empty trampoline block before reaching a
+// shared exit, and checks that removeEmptyBlocks redirects both arms straight
+// to the exit and deletes the trampoline.
+func TestRemoveEmptyBlocksElidesUnconditionalTrampoline(t *testing.T) {
+	i1 := types.NewInt(1)
+	i32 := types.NewInt(32)
+	f := ir.NewFunction("f", types.Void, ir.NewParam("cond", i1))
+	cond := f.Params()[0]
+
+	entry := ir.NewBasicBlock("entry")
+	a := ir.NewBasicBlock("a")
+	bBlock := ir.NewBasicBlock("b")
+	trampoline := ir.NewBasicBlock("trampoline")
+	end := ir.NewBasicBlock("end")
+	f.AppendBlock(entry)
+	f.AppendBlock(a)
+	f.AppendBlock(bBlock)
+	f.AppendBlock(trampoline)
+	f.AppendBlock(end)
+
+	entry.SetTerm(ir.NewCondBr(cond, a, bBlock))
+
+	b := ir.NewBuilder()
+	b.SetInsertPoint(a)
+	b.EmitBinOp(ir.BinOpAdd, constant.NewInt(i32, 1), constant.NewInt(i32, 1))
+	b.EmitBr(trampoline)
+
+	b.SetInsertPoint(bBlock)
+	b.EmitBinOp(ir.BinOpAdd, constant.NewInt(i32, 2), constant.NewInt(i32, 2))
+	b.EmitBr(trampoline)
+
+	trampoline.SetTerm(ir.NewBr(end))
+	end.SetTerm(ir.NewRet(nil))
+
+	removeEmptyBlocks(f)
+	f.AssignIDs()
+
+	if err := SanityCheck(f); err != nil {
+		t.Fatalf("SanityCheck failed after removing empty blocks: %v", err)
+	}
+
+	want := `define void @f(i1 %cond) {
+entry:
+	br i1 %cond, label %a, label %b
+a:
+	%0 = add i32 1, 1
+	br label %end
+b:
+	%1 = add i32 2, 2
+	br label %end
+end:
+	ret void
+}`
+	if got := f.String(); got != want {
+		t.Errorf("removeEmptyBlocks mismatch;\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// TestSanityCheckAcceptsValidLoop builds a well-formed single-induction-
+// variable loop, with the phi's back-edge incoming value sourced from the
+// loop block itself, and checks that both SanityCheck and a SimplifyCFG pass
+// (which has nothing to simplify here) leave it untouched and error-free.
+func TestSanityCheckAcceptsValidLoop(t *testing.T) {
+	i32 := types.NewInt(32)
+	f := ir.NewFunction("f", i32)
+
+	entry := ir.NewBasicBlock("entry")
+	loop := ir.NewBasicBlock("loop")
+	exit := ir.NewBasicBlock("exit")
+	f.AppendBlock(entry)
+	f.AppendBlock(loop)
+	f.AppendBlock(exit)
+
+	b := ir.NewBuilder()
+	b.SetInsertPoint(entry)
+	b.EmitBr(loop)
+
+	b.SetInsertPoint(loop)
+	phi := b.EmitPhi(i32)
+	iNext := b.EmitBinOp(ir.BinOpAdd, phi, constant.NewInt(i32, 1))
+	phi.SetIncs([]*ir.Incoming{
+		{X: constant.NewInt(i32, 0), Pred: entry},
+		{X: iNext, Pred: loop},
+	})
+	cond := b.EmitICmp(ir.ICmpPredSLT, iNext, constant.NewInt(i32, 10))
+	b.EmitCondBr(cond, loop, exit)
+
+	b.SetInsertPoint(exit)
+	b.EmitRet(iNext)
+
+	f.AssignIDs()
+	if err := SanityCheck(f); err != nil {
+		t.Fatalf("SanityCheck rejected a valid loop: %v", err)
+	}
+
+	want := `define i32 @f() {
+entry:
+	br label %loop
+loop:
+	%0 = phi i32 [ 0, %entry ], [ %1, %loop ]
+	%1 = add i32 %0, 1
+	%2 = icmp slt i32 %1, 10
+	br i1 %2, label %loop, label %exit
+exit:
+	ret i32 %1
+}`
+	if got := f.String(); got != want {
+		t.Errorf("valid loop mismatch;\ngot:\n%s\nwant:\n%s", got, want)
+	}
+
+	SimplifyCFG(f)
+	if err := SanityCheck(f); err != nil {
+		t.Fatalf("SanityCheck failed after a no-op SimplifyCFG: %v", err)
+	}
+	if got := f.String(); got != want {
+		t.Errorf("SimplifyCFG altered a loop it had nothing to simplify;\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// TestSanityCheckRejectsNonDominatingPhiOperand builds a diamond where one
+// phi incoming value is deliberately sourced from a sibling block that does
+// not dominate it, and checks that SanityCheck reports the violation instead
+// of accepting the malformed IR.
+func TestSanityCheckRejectsNonDominatingPhiOperand(t *testing.T) {
+	i32 := types.NewInt(32)
+	i1 := types.NewInt(1)
+	f := ir.NewFunction("f", i32, ir.NewParam("cond", i1))
+	cond := f.Params()[0]
+
+	entry := ir.NewBasicBlock("entry")
+	left := ir.NewBasicBlock("left")
+	right := ir.NewBasicBlock("right")
+	end := ir.NewBasicBlock("end")
+	f.AppendBlock(entry)
+	f.AppendBlock(left)
+	f.AppendBlock(right)
+	f.AppendBlock(end)
+
+	b := ir.NewBuilder()
+	b.SetInsertPoint(entry)
+	b.EmitCondBr(cond, left, right)
+
+	b.SetInsertPoint(left)
+	v := b.EmitBinOp(ir.BinOpAdd, constant.NewInt(i32, 1), constant.NewInt(i32, 1))
+	b.EmitBr(end)
+
+	b.SetInsertPoint(right)
+	b.EmitBr(end)
+
+	b.SetInsertPoint(end)
+	// v is defined in left, which does not dominate right; attributing v to
+	// right's incoming value is invalid SSA.
+	phi := b.EmitPhi(i32,
+		&ir.Incoming{X: v, Pred: right},
+		&ir.Incoming{X: constant.NewInt(i32, 0), Pred: left},
+	)
+	b.EmitRet(phi)
+
+	f.AssignIDs()
+	if err := SanityCheck(f); err == nil {
+		t.Fatal("SanityCheck accepted a phi operand that does not dominate its predecessor block")
+	}
+}
@@ -0,0 +1,163 @@
+// === [ Terminators ] ============================================================
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#terminator-instructions
+
+package ir
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/llir/llvm/ir/value"
+)
+
+// TermRet represents a ret terminator.
+type TermRet struct {
+	parent *BasicBlock
+	// Return value, or nil if ret void.
+	x value.Value
+}
+
+// NewRet returns a new ret terminator based on the given return value. A nil
+// return value indicates a ret void terminator.
+func NewRet(x value.Value) *TermRet {
+	return &TermRet{x: x}
+}
+
+// Parent returns the parent basic block of the terminator.
+func (term *TermRet) Parent() *BasicBlock {
+	return term.parent
+}
+
+// SetParent sets the parent basic block of the terminator.
+func (term *TermRet) SetParent(parent *BasicBlock) {
+	term.parent = parent
+}
+
+// X returns the return value of the ret terminator, and a boolean indicating
+// if a return value is present.
+func (term *TermRet) X() (value.Value, bool) {
+	if term.x != nil {
+		return term.x, true
+	}
+	return nil, false
+}
+
+// SetX sets the return value of the ret terminator. A nil x turns the
+// terminator into a ret void.
+func (term *TermRet) SetX(x value.Value) {
+	term.x = x
+}
+
+// String returns the LLVM syntax representation of the terminator.
+func (term *TermRet) String() string {
+	if x, ok := term.X(); ok {
+		return fmt.Sprintf("ret %s %s", x.Type(), x.Ident())
+	}
+	return "ret void"
+}
+
+// TermBr represents an unconditional br terminator.
+type TermBr struct {
+	parent *BasicBlock
+	target *BasicBlock
+}
+
+// NewBr returns a new unconditional br terminator based on the given target
+// basic block.
+func NewBr(target *BasicBlock) *TermBr {
+	return &TermBr{target: target}
+}
+
+// Parent returns the parent basic block of the terminator.
+func (term *TermBr) Parent() *BasicBlock {
+	return term.parent
+}
+
+// SetParent sets the parent basic block of the terminator.
+func (term *TermBr) SetParent(parent *BasicBlock) {
+	term.parent = parent
+}
+
+// Target returns the target basic block of the br terminator.
+func (term *TermBr) Target() *BasicBlock {
+	return term.target
+}
+
+// SetTarget sets the target basic block of the br terminator.
+func (term *TermBr) SetTarget(target *BasicBlock) {
+	term.target = target
+}
+
+// String returns the LLVM syntax representation of the terminator.
+func (term *TermBr) String() string {
+	return fmt.Sprintf("br label %s", term.Target().Ident())
+}
+
+// TermCondBr represents a conditional br terminator.
+type TermCondBr struct {
+	parent      *BasicBlock
+	cond        value.Value
+	targetTrue  *BasicBlock
+	targetFalse *BasicBlock
+}
+
+// NewCondBr returns a new conditional br terminator based on the given
+// branching condition and conditional target basic blocks.
+func NewCondBr(cond value.Value, targetTrue, targetFalse *BasicBlock) *TermCondBr {
+	return &TermCondBr{cond: cond, targetTrue: targetTrue, targetFalse: targetFalse}
+}
+
+// Parent returns the parent basic block of the terminator.
+func (term *TermCondBr) Parent() *BasicBlock {
+	return term.parent
+}
+
+// SetParent sets the parent basic block of the terminator.
+func (term *TermCondBr) SetParent(parent *BasicBlock) {
+	term.parent = parent
+}
+
+// Cond returns the branching condition of the conditional br terminator.
+func (term *TermCondBr) Cond() value.Value {
+	return term.cond
+}
+
+// SetCond sets the branching condition of the conditional br terminator.
+func (term *TermCondBr) SetCond(cond value.Value) {
+	term.cond = cond
+}
+
+// TargetTrue returns the true target basic block of the conditional br
+// terminator.
+func (term *TermCondBr) TargetTrue() *BasicBlock {
+	return term.targetTrue
+}
+
+// SetTargetTrue sets the true target basic block of the conditional br
+// terminator.
+func (term *TermCondBr) SetTargetTrue(target *BasicBlock) {
+	term.targetTrue = target
+}
+
+// TargetFalse returns the false target basic block of the conditional br
+// terminator.
+func (term *TermCondBr) TargetFalse() *BasicBlock {
+	return term.targetFalse
+}
+
+// SetTargetFalse sets the false target basic block of the conditional br
+// terminator.
+func (term *TermCondBr) SetTargetFalse(target *BasicBlock) {
+	term.targetFalse = target
+}
+
+// String returns the LLVM syntax representation of the terminator.
+func (term *TermCondBr) String() string {
+	buf := &bytes.Buffer{}
+	cond := term.Cond()
+	fmt.Fprintf(buf, "br %s %s, label %s, label %s",
+		cond.Type(), cond.Ident(), term.TargetTrue().Ident(), term.TargetFalse().Ident())
+	return buf.String()
+}
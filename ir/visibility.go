@@ -0,0 +1,65 @@
+// === [ Visibility styles ] =====================================================
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#visibility-styles
+
+package ir
+
+import "fmt"
+
+// Visibility specifies the visibility style of a global identifier.
+type Visibility int
+
+// Visibility styles.
+const (
+	// VisibilityNone specifies no visibility style, the default style used
+	// when a global identifier does not specify one explicitly.
+	VisibilityNone Visibility = iota
+	VisibilityDefault
+	VisibilityHidden
+	VisibilityProtected
+)
+
+// String returns the LLVM syntax representation of the visibility style.
+func (visibility Visibility) String() string {
+	m := map[Visibility]string{
+		VisibilityNone:      "",
+		VisibilityDefault:   "default",
+		VisibilityHidden:    "hidden",
+		VisibilityProtected: "protected",
+	}
+	if s, ok := m[visibility]; ok {
+		return s
+	}
+	panic(fmt.Sprintf("support for visibility style %d not yet implemented", int(visibility)))
+}
+
+// === [ DLL storage classes ] ===================================================
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#dllstorageclass
+
+// DLLStorageClass specifies the DLL storage class of a global identifier.
+type DLLStorageClass int
+
+// DLL storage classes.
+const (
+	// DLLStorageClassNone specifies no DLL storage class, the default used
+	// when a global identifier does not specify one explicitly.
+	DLLStorageClassNone DLLStorageClass = iota
+	DLLStorageClassImport
+	DLLStorageClassExport
+)
+
+// String returns the LLVM syntax representation of the DLL storage class.
+func (class DLLStorageClass) String() string {
+	m := map[DLLStorageClass]string{
+		DLLStorageClassNone:   "",
+		DLLStorageClassImport: "dllimport",
+		DLLStorageClassExport: "dllexport",
+	}
+	if s, ok := m[class]; ok {
+		return s
+	}
+	panic(fmt.Sprintf("support for DLL storage class %d not yet implemented", int(class)))
+}